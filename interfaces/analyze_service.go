@@ -4,9 +4,18 @@ import "relatorios/models"
 
 type AnalyzeService interface {
 	Execute(text string) *models.ClassificationResult
+	ExecuteDocument(document models.DocumentMetadata) *models.ClassificationResult
+	MatchRules(document models.DocumentMetadata) []models.RuleMatch
+	MatchKeywords(text string, documentType string) []string
 	GetRules() []models.DocumentRule
 	ReloadRules() error
 	GetRulesFilePath() string
-	SetRules(rules []models.DocumentRule)
+	SetRules(rules []models.DocumentRule) error
 	SetRulesFile(filePath string) error
+	GetNormalizationOptions() models.NormalizationOptions
+	SetNormalizationOptions(options models.NormalizationOptions)
+}
+
+type AnalyzeServiceProvider interface {
+	GetAnalyzeService() AnalyzeService
 }
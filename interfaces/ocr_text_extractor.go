@@ -0,0 +1,7 @@
+package interfaces
+
+import "relatorios/models"
+
+type OCRTextExtractor interface {
+	ExtractTextWithOCR(filePath string, options models.OCROptions) (models.DocumentMetadata, error)
+}
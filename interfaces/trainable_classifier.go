@@ -0,0 +1,7 @@
+package interfaces
+
+import "relatorios/models"
+
+type TrainableClassifier interface {
+	Train(documents []models.DocumentMetadata) error
+}
@@ -27,11 +27,28 @@ func main() {
 
 	extractorFactory := extractors.NewDocumentExtractorFactory()
 	analyzeDocumentService := services.NewAnalyzeDocumentService(rulesFile)
+
+	normalizationFile := filepath.Join(configDir, "normalization.json")
+	normalizationOptions, err := models.LoadNormalizationOptionsFromJSON(normalizationFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	analyzeDocumentService.SetNormalizationOptions(normalizationOptions)
 	classifier := classifiers.NewDocumentClassifier(analyzeDocumentService)
 
+	ocrOptions, err := models.LoadOCROptionsFromJSON(filepath.Join(configDir, "ocr.json"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
 	config := models.ProcessingConfig{
-		OutputDirectory: "./output",
-		MoveFiles:       false,
+		OutputDirectory:     "./output",
+		MoveFiles:           false,
+		ReviewThreshold:     0.5,
+		ReviewQueueFile:     filepath.Join(configDir, "review_queue.json"),
+		LabeledExamplesFile: filepath.Join(configDir, "labeled_examples.json"),
+		ArchivePolicy:       models.ArchivePolicyExtract,
+		OCR:                 models.DefaultOCROptions().Merge(ocrOptions),
 	}
 
 	processingService := services.NewDocumentProcessingService(
@@ -40,7 +57,22 @@ func main() {
 		config,
 	)
 
-	consoleInterface := ui.NewConsoleInterface(processingService)
+	exemplarModelFile := filepath.Join(configDir, "exemplars.json")
+	processingService.AddClassifier(classifiers.NewNearestNeighborClassifier(exemplarModelFile, classifier))
+
+	ruleDiffService := services.NewRuleDiffService(analyzeDocumentService)
+	keywordService := services.NewKeywordSuggestionService(processingService)
+	feedbackService := services.NewFeedbackService(processingService)
+	coverageService := services.NewRuleCoverageService(analyzeDocumentService)
+
+	consoleInterface := ui.NewConsoleInterface(
+		processingService,
+		ruleDiffService,
+		keywordService,
+		feedbackService,
+		coverageService,
+		normalizationFile,
+	)
 
 	var initialPath string
 	if len(os.Args) > 1 {
@@ -0,0 +1,16 @@
+package models
+
+type MisleadingKeyword struct {
+	Keyword      string   `json:"keyword"`
+	DocumentType string   `json:"documentType"`
+	Count        int      `json:"count"`
+	SampleFiles  []string `json:"sampleFiles"`
+}
+
+type CorrectionReport struct {
+	TotalExamples      int                 `json:"totalExamples"`
+	Corrections        int                 `json:"corrections"`
+	UnreadableExamples int                 `json:"unreadableExamples"`
+	Confusions         []DocumentMove      `json:"confusions"`
+	MisleadingKeywords []MisleadingKeyword `json:"misleadingKeywords"`
+}
@@ -5,11 +5,107 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 )
 
 type DocumentRule struct {
-	Type     string   `json:"type"`
-	Keywords []string `json:"keywords"`
+	Type            string              `json:"type"`
+	Keywords        []string            `json:"keywords"`
+	SectionKeywords map[string][]string `json:"sectionKeywords,omitempty"`
+	Fields          map[string]string   `json:"fields,omitempty"`
+	Pages           []int               `json:"pages,omitempty"`
+	Languages       []string            `json:"languages,omitempty"`
+	Structure       *StructureRule      `json:"structure,omitempty"`
+
+	fieldPatterns map[string]*regexp.Regexp
+}
+
+type RuleMatch struct {
+	Rule             DocumentRule
+	Keywords         []string
+	KeywordPages     map[string][]int
+	SectionMatches   []string
+	FieldMatches     []string
+	StructureMatches []string
+}
+
+func (m RuleMatch) Score() int {
+	return len(m.Keywords) + len(m.SectionMatches) + len(m.FieldMatches) + len(m.StructureMatches)
+}
+
+func (m RuleMatch) AllMatches() []string {
+	matches := append([]string{}, m.StructureMatches...)
+	matches = append(matches, m.FieldMatches...)
+	matches = append(matches, m.SectionMatches...)
+	return append(matches, m.Keywords...)
+}
+
+func (r DocumentRule) AppliesToPage(number int, pageCount int) bool {
+	if len(r.Pages) == 0 {
+		return true
+	}
+
+	for _, page := range r.Pages {
+		if page == number || (page < 0 && pageCount+page+1 == number) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r DocumentRule) AppliesToLanguage(language string) bool {
+	if language == "" || len(r.Languages) == 0 {
+		return true
+	}
+
+	for _, ruleLanguage := range r.Languages {
+		if strings.EqualFold(ruleLanguage, language) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r DocumentRule) FieldPattern(name string) *regexp.Regexp {
+	return r.fieldPatterns[name]
+}
+
+func CompileFieldPatterns(rules []DocumentRule) error {
+	var problems []string
+
+	for i := range rules {
+		rules[i].fieldPatterns = make(map[string]*regexp.Regexp, len(rules[i].Fields))
+		for name, expression := range rules[i].Fields {
+			pattern, err := regexp.Compile("(?i)" + expression)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s field %q: %v", rules[i].Type, name, err))
+				continue
+			}
+			rules[i].fieldPatterns[name] = pattern
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid field patterns: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func ValidateDocumentType(documentType string) error {
+	trimmed := strings.TrimSpace(documentType)
+	if trimmed == "" {
+		return fmt.Errorf("document type is empty")
+	}
+	if trimmed == "." || strings.Contains(trimmed, "..") || strings.ContainsAny(trimmed, `/\:`) {
+		return fmt.Errorf("invalid document type %q: it must not contain path separators or \"..\"", documentType)
+	}
+	return nil
 }
 
 func LoadRulesFromJSON(filePath string) ([]DocumentRule, error) {
@@ -24,7 +120,11 @@ func LoadRulesFromJSON(filePath string) ([]DocumentRule, error) {
 		fmt.Printf("      \"keyword1\",\n")
 		fmt.Printf("      \"keyword2\",\n")
 		fmt.Printf("      \"key phrase also works\"\n")
-		fmt.Printf("    ]\n")
+		fmt.Printf("    ],\n")
+		fmt.Printf("    \"sectionKeywords\": {\"header\": [\"cnpj\"]}   (optional: header, footer, ...)\n")
+		fmt.Printf("    \"fields\": {\"nfe.cfop\": \"^5102\"}   (optional: metadata field -> regular expression)\n")
+		fmt.Printf("    \"pages\": [1, -1]   (optional: only match keywords on these pages, -1 is the last page)\n")
+		fmt.Printf("    \"languages\": [\"por\"]   (optional: por, eng, spa)\n")
 		fmt.Printf("  },\n")
 		fmt.Printf("]\n\n")
 
@@ -64,6 +164,10 @@ func LoadRulesFromJSON(filePath string) ([]DocumentRule, error) {
 		return nil, fmt.Errorf("failed to decode JSON rules: %w", err)
 	}
 
+	if err := CompileFieldPatterns(rules); err != nil {
+		return nil, fmt.Errorf("failed to validate rules: %w", err)
+	}
+
 	return rules, nil
 }
 
@@ -1,16 +1,115 @@
 package models
 
+import (
+	"strings"
+	"time"
+)
+
+type TypeCandidate struct {
+	DocumentType string `json:"documentType"`
+	Score        int    `json:"score"`
+}
+
 type DocumentClassification struct {
-	DocumentType string   `json:"documentType"`
-	Keywords     []string `json:"keywords"`
+	DocumentType   string           `json:"documentType"`
+	Keywords       []string         `json:"keywords"`
+	Confidence     float64          `json:"confidence"`
+	KeywordPages   map[string][]int `json:"keywordPages,omitempty"`
+	Candidates     []TypeCandidate  `json:"candidates,omitempty"`
+	Similarity     float64          `json:"similarity,omitempty"`
+	NearestExample string           `json:"nearestExample,omitempty"`
+	NeedsReview    bool             `json:"needsReview,omitempty"`
 }
 
 type DocumentMetadata struct {
 	Filename       string                  `json:"filename"`
 	Text           string                  `json:"text"`
+	Pages          []DocumentPage          `json:"pages,omitempty"`
+	Language       string                  `json:"language,omitempty"`
+	Summary        string                  `json:"summary,omitempty"`
+	Structure      *SpreadsheetStructure   `json:"structure,omitempty"`
+	Metadata       map[string]string       `json:"metadata,omitempty"`
+	Sections       map[string]string       `json:"sections,omitempty"`
+	Attachments    []EmbeddedFile          `json:"-"`
+	Info           *DocumentInfo           `json:"info,omitempty"`
+	Warnings       []ExtractionWarning     `json:"warnings,omitempty"`
 	Classification *DocumentClassification `json:"classification,omitempty"`
 }
 
+type DocumentInfo struct {
+	Path         string     `json:"path"`
+	Size         int64      `json:"size"`
+	SHA256       string     `json:"sha256"`
+	MimeType     string     `json:"mimeType,omitempty"`
+	PageCount    int        `json:"pageCount,omitempty"`
+	SheetCount   int        `json:"sheetCount,omitempty"`
+	SlideCount   int        `json:"slideCount,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Author       string     `json:"author,omitempty"`
+	Created      *time.Time `json:"created,omitempty"`
+	Modified     *time.Time `json:"modified,omitempty"`
+	DateTaken    *time.Time `json:"dateTaken,omitempty"`
+	Extractor    string     `json:"extractor"`
+	ExtractionMs int64      `json:"extractionMs"`
+}
+
+type DocumentPage struct {
+	Number int      `json:"number"`
+	Lines  []string `json:"lines"`
+}
+
+func NewDocumentPage(number int, text string) DocumentPage {
+	page := DocumentPage{Number: number, Lines: []string{}}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimRight(line, " \t\r\f")
+		if strings.TrimSpace(line) == "" {
+			if len(page.Lines) == 0 || page.Lines[len(page.Lines)-1] == "" {
+				continue
+			}
+			line = ""
+		}
+		page.Lines = append(page.Lines, line)
+	}
+	if len(page.Lines) > 0 && page.Lines[len(page.Lines)-1] == "" {
+		page.Lines = page.Lines[:len(page.Lines)-1]
+	}
+	return page
+}
+
+func (p DocumentPage) Text() string {
+	return strings.Join(p.Lines, "\n")
+}
+
+func (d *DocumentMetadata) SetPages(pages []DocumentPage) {
+	d.Pages = pages
+
+	texts := make([]string, 0, len(pages))
+	for _, page := range pages {
+		texts = append(texts, page.Text())
+	}
+	d.Text = strings.Join(texts, "\n\n")
+}
+
+func (d DocumentMetadata) AllPages() []DocumentPage {
+	if len(d.Pages) > 0 {
+		return d.Pages
+	}
+	return []DocumentPage{NewDocumentPage(1, d.Text)}
+}
+
+type ExtractionWarning struct {
+	Source  string `json:"source"`
+	Page    int    `json:"page,omitempty"`
+	Message string `json:"message"`
+}
+
+type EmbeddedFile struct {
+	Name  string
+	Data  []byte
+	Path  string
+	Error string
+}
+
 type ClassificationResult struct {
 	Classification DocumentClassification `json:"classification"`
 	Summary        string                 `json:"summary,omitempty"`
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewDocumentPage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", []string{}},
+		{"blank lines only", "\n \n\t\n", []string{}},
+		{"paragraphs", "Título\n\nPrimeiro parágrafo\ncontinua\n\n\n\nSegundo parágrafo", []string{"Título", "", "Primeiro parágrafo", "continua", "", "Segundo parágrafo"}},
+		{"surrounding blank lines", "\n\n  Recuado  \n\n", []string{"  Recuado"}},
+		{"windows line endings", "Linha 1\r\n\r\nLinha 2\r\n", []string{"Linha 1", "", "Linha 2"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page := NewDocumentPage(3, test.text)
+			if page.Number != 3 || !reflect.DeepEqual(page.Lines, test.want) {
+				t.Errorf("NewDocumentPage(%q) = %+v, want lines %q", test.text, page, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package models
+
+type KeywordSuggestion struct {
+	Keyword   string  `json:"keyword"`
+	Score     float64 `json:"score"`
+	Precision float64 `json:"precision"`
+	Coverage  float64 `json:"coverage"`
+}
+
+type TypeKeywordSuggestions struct {
+	Type        string              `json:"type"`
+	SampleCount int                 `json:"sampleCount"`
+	Suggestions []KeywordSuggestion `json:"suggestions"`
+}
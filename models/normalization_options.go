@@ -0,0 +1,53 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type NormalizationOptions struct {
+	Stemming         bool `json:"stemming"`
+	RemoveStopWords  bool `json:"removeStopWords"`
+	NormalizeNumbers bool `json:"normalizeNumbers"`
+}
+
+func (o NormalizationOptions) Enabled() bool {
+	return o.Stemming || o.RemoveStopWords || o.NormalizeNumbers
+}
+
+func LoadNormalizationOptionsFromJSON(filePath string) (NormalizationOptions, error) {
+	var options NormalizationOptions
+
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return options, nil
+	}
+	if err != nil {
+		return options, fmt.Errorf("failed to read normalization options: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &options); err != nil {
+		return options, fmt.Errorf("failed to decode normalization options: %w", err)
+	}
+
+	return options, nil
+}
+
+func SaveNormalizationOptionsToJSON(filePath string, options NormalizationOptions) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for normalization options: %w", err)
+	}
+
+	data, err := json.MarshalIndent(options, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode normalization options: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to save normalization options: %w", err)
+	}
+
+	return nil
+}
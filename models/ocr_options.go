@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	FolderOCROptionsFile = ".ocr.json"
+
+	defaultOCRDPI            = 300
+	defaultOCRTimeoutSeconds = 120
+)
+
+type OCROptions struct {
+	Languages            string `json:"languages,omitempty"`
+	PageSegmentationMode *int   `json:"psm,omitempty"`
+	EngineMode           *int   `json:"oem,omitempty"`
+	TessdataDir          string `json:"tessdataDir,omitempty"`
+	DPI                  int    `json:"dpi,omitempty"`
+	TimeoutSeconds       int    `json:"timeoutSeconds,omitempty"`
+}
+
+func DefaultOCROptions() OCROptions {
+	return OCROptions{
+		TimeoutSeconds: defaultOCRTimeoutSeconds,
+	}
+}
+
+func (o OCROptions) Merge(override OCROptions) OCROptions {
+	if override.Languages != "" {
+		o.Languages = override.Languages
+	}
+	if override.PageSegmentationMode != nil {
+		o.PageSegmentationMode = override.PageSegmentationMode
+	}
+	if override.EngineMode != nil {
+		o.EngineMode = override.EngineMode
+	}
+	if override.TessdataDir != "" {
+		o.TessdataDir = override.TessdataDir
+	}
+	if override.DPI > 0 {
+		o.DPI = override.DPI
+	}
+	if override.TimeoutSeconds > 0 {
+		o.TimeoutSeconds = override.TimeoutSeconds
+	}
+	return o
+}
+
+func (o OCROptions) Timeout() time.Duration {
+	if o.TimeoutSeconds <= 0 {
+		return defaultOCRTimeoutSeconds * time.Second
+	}
+	return time.Duration(o.TimeoutSeconds) * time.Second
+}
+
+func (o OCROptions) RasterDPI() int {
+	if o.DPI <= 0 {
+		return defaultOCRDPI
+	}
+	return o.DPI
+}
+
+func (o OCROptions) LanguageList() []string {
+	languages := []string{}
+	for _, language := range strings.Split(o.Languages, "+") {
+		if language = strings.TrimSpace(language); language != "" {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+func RuleSetOCROptionsFile(rulesFile string) string {
+	return strings.TrimSuffix(rulesFile, filepath.Ext(rulesFile)) + ".ocr.json"
+}
+
+func LoadOCROptionsFromJSON(filePath string) (OCROptions, error) {
+	var options OCROptions
+
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return options, nil
+	}
+	if err != nil {
+		return options, fmt.Errorf("failed to read OCR options: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &options); err != nil {
+		return options, fmt.Errorf("failed to decode OCR options %s: %w", filePath, err)
+	}
+
+	return options, nil
+}
@@ -1,19 +1,52 @@
 package models
 
+const (
+	ArchivePolicyExtract = "extract"
+	ArchivePolicyCopy    = "copy"
+)
+
 type ProcessingConfig struct {
-	OutputDirectory string
-	MoveFiles       bool
+	OutputDirectory     string
+	MoveFiles           bool
+	ReviewThreshold     float64
+	ReviewQueueFile     string
+	LabeledExamplesFile string
+	ArchivePolicy       string
+	OCR                 OCROptions
 }
 
 type ProcessingResult struct {
-	ProcessedCount int
-	FailedCount    int
-	Results        []FileProcessingResult
+	ProcessedCount     int                    `json:"processedCount"`
+	FailedCount        int                    `json:"failedCount"`
+	PendingReviewCount int                    `json:"pendingReviewCount"`
+	SkippedCount       int                    `json:"skippedCount"`
+	Results            []FileProcessingResult `json:"results"`
+	ReportPath         string                 `json:"-"`
 }
 
 type FileProcessingResult struct {
-	Filename     string
-	Success      bool
-	DocumentType string
-	Error        string
+	Filename      string        `json:"filename"`
+	Success       bool          `json:"success"`
+	DocumentType  string        `json:"documentType,omitempty"`
+	Confidence    float64       `json:"confidence,omitempty"`
+	PendingReview bool          `json:"pendingReview,omitempty"`
+	Skipped       bool          `json:"skipped,omitempty"`
+	Summary       string        `json:"summary,omitempty"`
+	Warning       string        `json:"warning,omitempty"`
+	Info          *DocumentInfo `json:"info,omitempty"`
+	Error         string        `json:"error,omitempty"`
+}
+
+func (r *ProcessingResult) Add(fileResult FileProcessingResult) {
+	switch {
+	case fileResult.Skipped:
+		r.SkippedCount++
+	case !fileResult.Success:
+		r.FailedCount++
+	case fileResult.PendingReview:
+		r.PendingReviewCount++
+	default:
+		r.ProcessedCount++
+	}
+	r.Results = append(r.Results, fileResult)
 }
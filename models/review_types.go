@@ -0,0 +1,84 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type ReviewItem struct {
+	ID             string                 `json:"id"`
+	SourcePath     string                 `json:"sourcePath"`
+	Filename       string                 `json:"filename"`
+	TextHash       string                 `json:"textHash"`
+	TextPreview    string                 `json:"textPreview"`
+	Classification DocumentClassification `json:"classification"`
+	QueuedAt       time.Time              `json:"queuedAt"`
+}
+
+type LabeledExample struct {
+	Path      string    `json:"path"`
+	Filename  string    `json:"filename"`
+	TextHash  string    `json:"textHash"`
+	Predicted string    `json:"predicted"`
+	Label     string    `json:"label"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func LoadReviewQueueFromJSON(filePath string) ([]ReviewItem, error) {
+	items := []ReviewItem{}
+	if err := loadJSONList(filePath, &items); err != nil {
+		return nil, fmt.Errorf("failed to load review queue: %w", err)
+	}
+	return items, nil
+}
+
+func SaveReviewQueueToJSON(filePath string, items []ReviewItem) error {
+	if err := saveJSONList(filePath, items); err != nil {
+		return fmt.Errorf("failed to save review queue: %w", err)
+	}
+	return nil
+}
+
+func LoadLabeledExamplesFromJSON(filePath string) ([]LabeledExample, error) {
+	examples := []LabeledExample{}
+	if err := loadJSONList(filePath, &examples); err != nil {
+		return nil, fmt.Errorf("failed to load labeled examples: %w", err)
+	}
+	return examples, nil
+}
+
+func SaveLabeledExamplesToJSON(filePath string, examples []LabeledExample) error {
+	if err := saveJSONList(filePath, examples); err != nil {
+		return fmt.Errorf("failed to save labeled examples: %w", err)
+	}
+	return nil
+}
+
+func loadJSONList(filePath string, target interface{}) error {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, target)
+}
+
+func saveJSONList(filePath string, items interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, data, 0644)
+}
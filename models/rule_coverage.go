@@ -0,0 +1,17 @@
+package models
+
+type KeywordCoverage struct {
+	Keyword      string         `json:"keyword"`
+	Types        []string       `json:"types"`
+	Hits         int            `json:"hits"`
+	WinningTypes map[string]int `json:"winningTypes"`
+	Ties         int            `json:"ties"`
+}
+
+type RuleCoverageReport struct {
+	TotalDocuments int               `json:"totalDocuments"`
+	TiedDocuments  int               `json:"tiedDocuments"`
+	Keywords       []KeywordCoverage `json:"keywords"`
+	DeadKeywords   []KeywordCoverage `json:"deadKeywords"`
+	SharedKeywords []KeywordCoverage `json:"sharedKeywords"`
+}
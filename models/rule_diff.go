@@ -0,0 +1,16 @@
+package models
+
+type DocumentMove struct {
+	FromType    string   `json:"fromType"`
+	ToType      string   `json:"toType"`
+	Count       int      `json:"count"`
+	SampleFiles []string `json:"sampleFiles"`
+}
+
+type RuleDiffReport struct {
+	RulesFileA       string         `json:"rulesFileA"`
+	RulesFileB       string         `json:"rulesFileB"`
+	TotalDocuments   int            `json:"totalDocuments"`
+	ChangedDocuments int            `json:"changedDocuments"`
+	Moves            []DocumentMove `json:"moves"`
+}
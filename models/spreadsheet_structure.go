@@ -0,0 +1,89 @@
+package models
+
+import "strings"
+
+type SheetStructure struct {
+	Name         string            `json:"name"`
+	Headers      []string          `json:"headers"`
+	ColumnCount  int               `json:"columnCount"`
+	RowCount     int               `json:"rowCount"`
+	HasTotalsRow bool              `json:"hasTotalsRow"`
+	Cells        []SpreadsheetCell `json:"cells,omitempty"`
+}
+
+type SpreadsheetCell struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+	Formula bool   `json:"formula,omitempty"`
+}
+
+type SpreadsheetStructure struct {
+	Sheets []SheetStructure `json:"sheets"`
+}
+
+type StructureRule struct {
+	SheetNames   []string `json:"sheetNames,omitempty"`
+	Headers      []string `json:"headers,omitempty"`
+	MinColumns   int      `json:"minColumns,omitempty"`
+	MaxColumns   int      `json:"maxColumns,omitempty"`
+	HasTotalsRow *bool    `json:"hasTotalsRow,omitempty"`
+}
+
+func NewSheetStructure(name string, rows [][]string) SheetStructure {
+	sheet := SheetStructure{
+		Name:    name,
+		Headers: []string{},
+	}
+
+	var lastRow []string
+	for _, row := range rows {
+		if isEmptyRow(row) {
+			continue
+		}
+
+		sheet.RowCount++
+		if len(row) > sheet.ColumnCount {
+			sheet.ColumnCount = len(row)
+		}
+
+		if len(sheet.Headers) == 0 {
+			for _, cell := range row {
+				sheet.Headers = append(sheet.Headers, strings.TrimSpace(cell))
+			}
+		}
+
+		lastRow = row
+	}
+
+	if sheet.RowCount > 1 {
+		sheet.HasTotalsRow = isTotalsRow(lastRow)
+	}
+
+	return sheet
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isTotalsRow(row []string) bool {
+	for _, cell := range row {
+		value := strings.ToLower(strings.TrimSpace(cell))
+		if value == "" {
+			continue
+		}
+
+		for _, prefix := range []string{"total", "totais", "subtotal", "soma", "sum", "grand total"} {
+			if strings.HasPrefix(value, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type TextIndex struct {
+	Source    string             `json:"source"`
+	Documents []DocumentMetadata `json:"documents"`
+}
+
+func LoadTextIndexFromJSON(filePath string) (*TextIndex, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read text index: %w", err)
+	}
+
+	var index TextIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode text index: %w", err)
+	}
+
+	return &index, nil
+}
+
+func SaveTextIndexToJSON(filePath string, index *TextIndex) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory for text index: %w", err)
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode text index to JSON: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to save text index: %w", err)
+	}
+
+	return nil
+}
@@ -2,19 +2,31 @@ package services
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"regexp"
 	"relatorios/models"
+	"relatorios/services/language"
+	"sort"
 	"strings"
 )
 
+const (
+	maxCandidates       = 3
+	minConfidentMatches = 3.0
+)
+
 type AnalyzeDocumentService struct {
-	rules     []models.DocumentRule
-	rulesFile string
+	rules                []models.DocumentRule
+	rulesFile            string
+	normalizationOptions models.NormalizationOptions
 }
 
 func NewAnalyzeDocumentService(rulesFile string) *AnalyzeDocumentService {
-	rules, _ := models.LoadRulesFromJSON(rulesFile)
+	rules, err := models.LoadRulesFromJSON(rulesFile)
+	if err != nil {
+		fmt.Printf("WARNING: %v\n", err)
+	}
 
 	return &AnalyzeDocumentService{
 		rules:     rules,
@@ -22,9 +34,13 @@ func NewAnalyzeDocumentService(rulesFile string) *AnalyzeDocumentService {
 	}
 }
 
-func (s *AnalyzeDocumentService) SetRules(rules []models.DocumentRule) {
+func (s *AnalyzeDocumentService) SetRules(rules []models.DocumentRule) error {
+	if err := models.CompileFieldPatterns(rules); err != nil {
+		return err
+	}
+
 	s.rules = rules
-	_ = models.SaveRulesToJSON(s.rulesFile, rules)
+	return models.SaveRulesToJSON(s.rulesFile, rules)
 }
 
 func (s *AnalyzeDocumentService) GetRules() []models.DocumentRule {
@@ -40,6 +56,14 @@ func (s *AnalyzeDocumentService) ReloadRules() error {
 	return nil
 }
 
+func (s *AnalyzeDocumentService) GetNormalizationOptions() models.NormalizationOptions {
+	return s.normalizationOptions
+}
+
+func (s *AnalyzeDocumentService) SetNormalizationOptions(options models.NormalizationOptions) {
+	s.normalizationOptions = options
+}
+
 func (s *AnalyzeDocumentService) GetRulesFilePath() string {
 	return s.rulesFile
 }
@@ -61,30 +85,37 @@ func (s *AnalyzeDocumentService) SetRulesFile(filePath string) error {
 }
 
 func (s *AnalyzeDocumentService) Execute(text string) *models.ClassificationResult {
+	return s.ExecuteDocument(models.DocumentMetadata{Text: text})
+}
+
+func (s *AnalyzeDocumentService) ExecuteDocument(document models.DocumentMetadata) *models.ClassificationResult {
+	text := document.Text
 	if text == "" {
 		return s.createResult("Empty Document", []string{"empty"})
 	}
 
-	normalizedText := s.normalizeText(text)
-
 	bestMatchCount := 0
 	bestType := ""
 	bestKeywords := []string{}
+	bestPages := map[string][]int{}
+	candidates := []models.TypeCandidate{}
 
-	for _, rule := range s.rules {
-		matchedKeywords := []string{}
+	for _, match := range s.MatchRules(document) {
+		rule := match.Rule
+		matchedKeywords := match.AllMatches()
 
-		for _, keyword := range rule.Keywords {
-			normalizedKeyword := strings.ToLower(keyword)
-			if strings.Contains(normalizedText, normalizedKeyword) {
-				matchedKeywords = append(matchedKeywords, keyword)
-			}
+		if len(matchedKeywords) > 0 {
+			candidates = append(candidates, models.TypeCandidate{
+				DocumentType: rule.Type,
+				Score:        len(matchedKeywords),
+			})
 		}
 
 		if len(matchedKeywords) > bestMatchCount {
 			bestMatchCount = len(matchedKeywords)
 			bestType = rule.Type
 			bestKeywords = matchedKeywords
+			bestPages = match.KeywordPages
 		}
 	}
 
@@ -93,10 +124,277 @@ func (s *AnalyzeDocumentService) Execute(text string) *models.ClassificationResu
 			bestKeywords = bestKeywords[:5]
 		}
 
-		return s.createResult(bestType, bestKeywords)
+		result := s.createResult(bestType, bestKeywords)
+		result.Classification.KeywordPages = s.keywordPagesFor(bestKeywords, bestPages)
+		result.Classification.Candidates = s.topCandidates(candidates)
+		result.Classification.Confidence = s.confidence(result.Classification.Candidates)
+		result.Summary = summarize(text, bestKeywords)
+		return result
+	}
+
+	result := s.createResult("Other", []string{"document", "text"})
+	result.Summary = summarize(text, nil)
+	return result
+}
+
+func (s *AnalyzeDocumentService) MatchRules(document models.DocumentMetadata) []models.RuleMatch {
+	documentLanguage := document.Language
+	if documentLanguage == "" {
+		documentLanguage = language.Detect(document.Text)
+	}
+
+	normalizedText := s.normalizeText(document.Text)
+	pages := document.AllPages()
+	normalizedPages := make([]string, len(pages))
+	for i, page := range pages {
+		normalizedPages[i] = s.normalizeText(page.Text())
+	}
+
+	matches := make([]models.RuleMatch, 0, len(s.rules))
+
+	for _, rule := range s.rules {
+		if !rule.AppliesToLanguage(documentLanguage) {
+			continue
+		}
+
+		ruleText := normalizedText
+		if len(rule.Pages) > 0 {
+			ruleText = s.normalizeText(s.rulePagesText(pages, rule))
+		}
+
+		keywords := s.matchRule(ruleText, rule)
+		var keywordPages map[string][]int
+		if len(document.Pages) > 0 {
+			keywordPages = s.matchPages(pages, normalizedPages, rule, keywords)
+		}
+
+		matches = append(matches, models.RuleMatch{
+			Rule:             rule,
+			Keywords:         keywords,
+			KeywordPages:     keywordPages,
+			SectionMatches:   s.matchSections(document.Sections, rule.SectionKeywords),
+			FieldMatches:     s.matchFields(document.Metadata, rule),
+			StructureMatches: s.matchStructure(document.Structure, rule.Structure),
+		})
 	}
 
-	return s.createResult("Other", []string{"document", "text"})
+	return matches
+}
+
+func (s *AnalyzeDocumentService) MatchKeywords(text string, documentType string) []string {
+	normalizedText := s.normalizeText(text)
+	matchedKeywords := []string{}
+
+	for _, rule := range s.rules {
+		if rule.Type == documentType {
+			matchedKeywords = append(matchedKeywords, s.matchRule(normalizedText, rule)...)
+		}
+	}
+
+	return matchedKeywords
+}
+
+func (s *AnalyzeDocumentService) matchRule(normalizedText string, rule models.DocumentRule) []string {
+	matchedKeywords := []string{}
+
+	for _, keyword := range rule.Keywords {
+		if s.normalizationOptions.Enabled() {
+			normalizedKeyword := normalizeTokens(keyword, s.normalizationOptions)
+			if normalizedKeyword != "" && strings.Contains(normalizedText, " "+normalizedKeyword+" ") {
+				matchedKeywords = append(matchedKeywords, keyword)
+			}
+			continue
+		}
+
+		normalizedKeyword := strings.ToLower(keyword)
+		if strings.Contains(normalizedText, normalizedKeyword) {
+			matchedKeywords = append(matchedKeywords, keyword)
+		}
+	}
+
+	return matchedKeywords
+}
+
+func (s *AnalyzeDocumentService) rulePagesText(pages []models.DocumentPage, rule models.DocumentRule) string {
+	texts := []string{}
+	for _, page := range pages {
+		if rule.AppliesToPage(page.Number, len(pages)) {
+			texts = append(texts, page.Text())
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+func (s *AnalyzeDocumentService) matchPages(pages []models.DocumentPage, normalizedPages []string, rule models.DocumentRule, keywords []string) map[string][]int {
+	keywordPages := make(map[string][]int)
+
+	for _, keyword := range keywords {
+		keywordRule := models.DocumentRule{Keywords: []string{keyword}}
+		for i, page := range pages {
+			if rule.AppliesToPage(page.Number, len(pages)) && len(s.matchRule(normalizedPages[i], keywordRule)) > 0 {
+				keywordPages[keyword] = append(keywordPages[keyword], page.Number)
+			}
+		}
+	}
+
+	return keywordPages
+}
+
+func (s *AnalyzeDocumentService) keywordPagesFor(keywords []string, pages map[string][]int) map[string][]int {
+	selected := make(map[string][]int)
+	for _, keyword := range keywords {
+		if numbers, exists := pages[keyword]; exists {
+			selected[keyword] = numbers
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil
+	}
+	return selected
+}
+
+func (s *AnalyzeDocumentService) matchSections(sections map[string]string, sectionKeywords map[string][]string) []string {
+	matched := []string{}
+	if len(sections) == 0 || len(sectionKeywords) == 0 {
+		return matched
+	}
+
+	names := make([]string, 0, len(sectionKeywords))
+	for name := range sectionKeywords {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		text, exists := sections[strings.ToLower(name)]
+		if !exists {
+			continue
+		}
+
+		sectionRule := models.DocumentRule{Keywords: sectionKeywords[name]}
+		for _, keyword := range s.matchRule(s.normalizeText(text), sectionRule) {
+			matched = append(matched, strings.ToLower(name)+" section:"+keyword)
+		}
+	}
+
+	return matched
+}
+
+func (s *AnalyzeDocumentService) matchFields(metadata map[string]string, rule models.DocumentRule) []string {
+	matched := []string{}
+	if len(metadata) == 0 || len(rule.Fields) == 0 {
+		return matched
+	}
+
+	names := make([]string, 0, len(rule.Fields))
+	for name := range rule.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, exists := metadata[name]
+		if !exists {
+			continue
+		}
+
+		pattern := rule.FieldPattern(name)
+		if pattern == nil {
+			continue
+		}
+
+		if pattern.MatchString(value) {
+			matched = append(matched, "field "+name)
+		}
+	}
+
+	return matched
+}
+
+func (s *AnalyzeDocumentService) matchStructure(structure *models.SpreadsheetStructure, rule *models.StructureRule) []string {
+	matched := []string{}
+	if structure == nil || rule == nil {
+		return matched
+	}
+
+	for _, sheetName := range rule.SheetNames {
+		for _, sheet := range structure.Sheets {
+			if strings.Contains(strings.ToLower(sheet.Name), strings.ToLower(sheetName)) {
+				matched = append(matched, "sheet:"+sheetName)
+				break
+			}
+		}
+	}
+
+	for _, header := range rule.Headers {
+		if s.hasHeader(structure, header) {
+			matched = append(matched, "header:"+header)
+		}
+	}
+
+	if rule.MinColumns > 0 || rule.MaxColumns > 0 {
+		for _, sheet := range structure.Sheets {
+			if sheet.ColumnCount >= rule.MinColumns && (rule.MaxColumns == 0 || sheet.ColumnCount <= rule.MaxColumns) {
+				matched = append(matched, fmt.Sprintf("columns:%d", sheet.ColumnCount))
+				break
+			}
+		}
+	}
+
+	if rule.HasTotalsRow != nil {
+		for _, sheet := range structure.Sheets {
+			if sheet.HasTotalsRow == *rule.HasTotalsRow {
+				matched = append(matched, fmt.Sprintf("totals row:%t", sheet.HasTotalsRow))
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+func (s *AnalyzeDocumentService) hasHeader(structure *models.SpreadsheetStructure, header string) bool {
+	normalizedHeader := strings.ToLower(strings.TrimSpace(header))
+
+	for _, sheet := range structure.Sheets {
+		for _, column := range sheet.Headers {
+			if strings.Contains(strings.ToLower(column), normalizedHeader) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *AnalyzeDocumentService) topCandidates(candidates []models.TypeCandidate) []models.TypeCandidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	if len(candidates) > maxCandidates {
+		candidates = candidates[:maxCandidates]
+	}
+
+	return candidates
+}
+
+func (s *AnalyzeDocumentService) confidence(candidates []models.TypeCandidate) float64 {
+	if len(candidates) == 0 {
+		return 0
+	}
+
+	best := float64(candidates[0].Score)
+	second := 0.0
+	if len(candidates) > 1 {
+		second = float64(candidates[1].Score)
+	}
+
+	margin := best / (best + second)
+	strength := math.Min(1, best/minConfidentMatches)
+
+	return margin * strength
 }
 
 func (s *AnalyzeDocumentService) createResult(documentType string, keywords []string) *models.ClassificationResult {
@@ -109,6 +407,10 @@ func (s *AnalyzeDocumentService) createResult(documentType string, keywords []st
 }
 
 func (s *AnalyzeDocumentService) normalizeText(text string) string {
+	if s.normalizationOptions.Enabled() {
+		return " " + normalizeTokens(text, s.normalizationOptions) + " "
+	}
+
 	normalized := strings.ToLower(strings.TrimSpace(text))
 
 	re := regexp.MustCompile(`\s+`)
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+	"testing"
+)
+
+func TestLoadRulesReportsInvalidFieldPatterns(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	writeTestFile(t, rulesFile, `[
+		{"type": "Invoice", "keywords": ["nota"], "fields": {"nfe.cfop": "^(5102", "nfe.model": "^55$"}},
+		{"type": "Receipt", "keywords": ["recibo"], "fields": {"nfe.total": "[0-9"}}
+	]`)
+
+	_, err := models.LoadRulesFromJSON(rulesFile)
+	if err == nil {
+		t.Fatal("rules with invalid field patterns were accepted")
+	}
+	for _, part := range []string{`Invoice field "nfe.cfop"`, `Receipt field "nfe.total"`} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("error %q does not mention %s", err, part)
+		}
+	}
+	if strings.Contains(err.Error(), "nfe.model") {
+		t.Errorf("error %q reports a valid pattern", err)
+	}
+
+	validFile := filepath.Join(t.TempDir(), "valid.json")
+	writeTestFile(t, validFile, `[{"type": "Invoice", "keywords": ["nota"]}]`)
+
+	service := NewAnalyzeDocumentService(validFile)
+	if err := service.SetRulesFile(rulesFile); err == nil {
+		t.Error("SetRulesFile accepted invalid field patterns")
+	}
+}
+
+func TestSetRulesRefusesInvalidFieldPatterns(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	writeTestFile(t, rulesFile, `[{"type": "Invoice", "keywords": ["nota"]}]`)
+
+	service := NewAnalyzeDocumentService(rulesFile)
+	invalid := []models.DocumentRule{{Type: "Receipt", Keywords: []string{"recibo"}, Fields: map[string]string{"nfe.total": "[0-9"}}}
+
+	if err := service.SetRules(invalid); err == nil {
+		t.Fatal("SetRules accepted invalid field patterns")
+	}
+	if rules := service.GetRules(); len(rules) != 1 || rules[0].Type != "Invoice" {
+		t.Errorf("rules after a refused SetRules = %+v", rules)
+	}
+	saved, err := models.LoadRulesFromJSON(rulesFile)
+	if err != nil || len(saved) != 1 || saved[0].Type != "Invoice" {
+		t.Errorf("rules file after a refused SetRules = %+v, %v", saved, err)
+	}
+
+	valid := []models.DocumentRule{{Type: "Receipt", Keywords: []string{"recibo"}, Fields: map[string]string{"nfe.total": "^[0-9]"}}}
+	if err := service.SetRules(valid); err != nil {
+		t.Fatalf("SetRules: %v", err)
+	}
+	if service.GetRules()[0].FieldPattern("nfe.total") == nil {
+		t.Error("SetRules did not compile the field patterns")
+	}
+	saved, err = models.LoadRulesFromJSON(rulesFile)
+	if err != nil || len(saved) != 1 || saved[0].Type != "Receipt" {
+		t.Errorf("rules file after SetRules = %+v, %v", saved, err)
+	}
+}
+
+func TestMatchRulesUsesFieldPatterns(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	writeTestFile(t, rulesFile, `[
+		{"type": "Sale", "keywords": ["nota fiscal"], "fields": {"nfe.cfop": "(^|,)5102", "nfe.emitter.state": "^sp$"}},
+		{"type": "Return", "keywords": ["nota fiscal"], "fields": {"nfe.cfop": "(^|,)1202"}}
+	]`)
+
+	service := NewAnalyzeDocumentService(rulesFile)
+	document := models.DocumentMetadata{
+		Text:     "Nota fiscal eletronica",
+		Metadata: map[string]string{"nfe.cfop": "5102,5405", "nfe.emitter.state": "SP"},
+	}
+
+	fields := make(map[string][]string)
+	for _, match := range service.MatchRules(document) {
+		fields[match.Rule.Type] = match.FieldMatches
+	}
+
+	if got := strings.Join(fields["Sale"], ","); got != "field nfe.cfop,field nfe.emitter.state" {
+		t.Errorf("Sale field matches = %q", got)
+	}
+	if len(fields["Return"]) != 0 {
+		t.Errorf("Return field matches = %q, want none", fields["Return"])
+	}
+
+	result := service.ExecuteDocument(document)
+	if result.Classification.DocumentType != "Sale" {
+		t.Errorf("document type = %q, want Sale", result.Classification.DocumentType)
+	}
+}
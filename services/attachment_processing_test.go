@@ -0,0 +1,203 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+	"testing"
+)
+
+func TestProcessAttachmentsSkipsUnsupportedEntries(t *testing.T) {
+	service, _, dir := newFeedbackTestService(t)
+
+	container := filepath.Join(dir, "mensagem.eml")
+	writeTestFile(t, container, "")
+
+	document := models.DocumentMetadata{
+		Attachments: []models.EmbeddedFile{
+			{Name: "nota.txt", Data: []byte("Nota fiscal eletronica, valor total R$ 100,00")},
+			{Name: "assinatura.p7s", Data: []byte{0x30, 0x82, 0x01}},
+		},
+	}
+
+	result := &models.ProcessingResult{}
+	for _, fileResult := range service.ProcessAttachments(container, document) {
+		result.Add(fileResult)
+	}
+
+	if result.FailedCount != 0 || result.SkippedCount != 1 || result.ProcessedCount+result.PendingReviewCount != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	skipped := result.Results[1]
+	if skipped.Filename != "mensagem.eml!/assinatura.p7s" || !skipped.Skipped || skipped.Warning != "Unsupported format" {
+		t.Errorf("skipped entry = %+v", skipped)
+	}
+}
+
+func TestProcessArchiveStagesNestedAndSpilledEntries(t *testing.T) {
+	service, _, dir := newFeedbackTestService(t)
+
+	var inner bytes.Buffer
+	writeTestZip(t, &inner, map[string][]byte{
+		"../fora.txt": []byte("fora"),
+		"nota.txt":    []byte("Nota fiscal eletronica, valor total R$ 100,00"),
+	})
+
+	large := bytes.Repeat([]byte("Contrato entre contratante e contratada, clausula primeira. "), 80000)
+
+	var outer bytes.Buffer
+	writeTestZip(t, &outer, map[string][]byte{
+		"interno.zip":  inner.Bytes(),
+		"contrato.txt": large,
+	})
+	archivePath := filepath.Join(dir, "lote.zip")
+	writeTestFile(t, archivePath, outer.String())
+
+	results := make(map[string]models.FileProcessingResult)
+	for _, result := range service.ProcessArchive(archivePath, service.DetectFormat(archivePath)) {
+		results[result.Filename] = result
+	}
+
+	if result := results["lote.zip!/contrato.txt"]; !result.Success || result.DocumentType != "Contract" {
+		t.Errorf("spilled entry = %+v", result)
+	}
+	if result := results["lote.zip!/interno.zip!/nota.txt"]; !result.Success || result.DocumentType != "Invoice" {
+		t.Errorf("nested entry = %+v", result)
+	}
+	if result := results["lote.zip!/interno.zip!/../fora.txt"]; result.Success || result.Error != "skipped: unsafe path" {
+		t.Errorf("traversal entry = %+v", result)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "output", stagingDirectory)); !os.IsNotExist(err) {
+		t.Errorf("staging directory left behind: %v", err)
+	}
+}
+
+func TestArchivesWithTheSameNameKeepTheirEntries(t *testing.T) {
+	service, _, dir := newFeedbackTestService(t)
+
+	contents := []string{
+		"Nota fiscal eletronica, valor total R$ 100,00",
+		"Nota fiscal de servico, valor total R$ 250,00",
+	}
+	for i, content := range contents {
+		var archive bytes.Buffer
+		writeTestZip(t, &archive, map[string][]byte{"nota.txt": []byte(content)})
+
+		archivePath := filepath.Join(dir, fmt.Sprintf("cliente%d", i+1), "lote.zip")
+		writeTestFile(t, archivePath, archive.String())
+
+		for _, result := range service.ProcessArchive(archivePath, service.DetectFormat(archivePath)) {
+			if !result.Success {
+				t.Errorf("result = %+v", result)
+			}
+		}
+	}
+
+	filed := make(map[string]bool)
+	for _, name := range []string{"nota.txt", "nota (2).txt"} {
+		data, err := os.ReadFile(filepath.Join(dir, "output", "Invoice", name))
+		if err != nil {
+			t.Fatalf("filed entry %s: %v", name, err)
+		}
+		filed[string(data)] = true
+	}
+	for _, content := range contents {
+		if !filed[content] {
+			t.Errorf("entry %q was overwritten", content)
+		}
+	}
+
+	first, err := service.createStagingDir(filepath.Join(dir, "cliente1", "lote.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := service.createStagingDir(filepath.Join(dir, "cliente2", "lote.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Errorf("containers with the same name share the staging directory %s", first)
+	}
+}
+
+func writeTestZip(t *testing.T, target *bytes.Buffer, entries map[string][]byte) {
+	t.Helper()
+	writer := zip.NewWriter(target)
+	for name, data := range entries {
+		w, err := writer.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEmbeddedEntriesUseContainerFolderOCROptions(t *testing.T) {
+	service, _, dir := newFeedbackTestService(t)
+
+	container := filepath.Join(dir, "mensagem.eml")
+	writeTestFile(t, container, "")
+	writeTestFile(t, filepath.Join(dir, models.FolderOCROptionsFile), `{"languages": "eng", "timeoutSeconds": 30}`)
+
+	document := models.DocumentMetadata{
+		Attachments: []models.EmbeddedFile{{Name: "nota.txt", Data: []byte("Nota fiscal eletronica, valor total R$ 100,00")}},
+	}
+
+	results := service.ProcessAttachments(container, document)
+	if len(results) != 1 || !results[0].Success {
+		t.Fatalf("results = %+v", results)
+	}
+	if options := service.ocrOptionsCache[dir].options; options.Languages != "eng" || options.TimeoutSeconds != 30 {
+		t.Errorf("OCR options = %+v, want the container folder's", options)
+	}
+
+	writeTestFile(t, filepath.Join(dir, models.FolderOCROptionsFile), `{"languages": `)
+
+	results = service.ProcessAttachments(container, document)
+	if len(results) != 1 || !strings.Contains(results[0].Warning, models.FolderOCROptionsFile) {
+		t.Errorf("results = %+v, want a warning about the folder OCR options", results)
+	}
+
+	if len(service.ocrOptionsCache) != 1 {
+		t.Errorf("OCR options cached for %d folders, want one", len(service.ocrOptionsCache))
+	}
+}
+
+func TestOCROptionsAreCachedPerFolder(t *testing.T) {
+	service, _, dir := newFeedbackTestService(t)
+
+	folder := filepath.Join(dir, "digitalizados")
+	writeTestFile(t, filepath.Join(folder, models.FolderOCROptionsFile), `{"languages": "spa"}`)
+
+	options, _ := service.ocrOptions(folder)
+	if options.Languages != "spa" {
+		t.Fatalf("OCR options = %+v, want the folder's", options)
+	}
+
+	entry := service.ocrOptionsCache[folder]
+	entry.options.Languages = "cached"
+	service.ocrOptionsCache[folder] = entry
+
+	if options, _ := service.ocrOptions(folder); options.Languages != "cached" {
+		t.Errorf("OCR options = %+v, want the cached options for an unchanged folder", options)
+	}
+	if options, _ := service.ocrOptions(dir); options.Languages == "cached" {
+		t.Error("another folder reused the cached options")
+	}
+
+	writeTestFile(t, filepath.Join(folder, models.FolderOCROptionsFile), `{"languages": "por+spa"}`)
+	if options, _ := service.ocrOptions(folder); options.Languages != "por+spa" {
+		t.Errorf("OCR options = %+v, want them reloaded after the folder file changed", options)
+	}
+}
@@ -16,8 +16,9 @@ func NewDocumentClassifier(analyzeService interfaces.AnalyzeService) *DocumentCl
 }
 
 func (c *DocumentClassifier) Classify(document models.DocumentMetadata) (models.DocumentMetadata, error) {
-	result := c.analyzeService.Execute(document.Text)
+	result := c.analyzeService.ExecuteDocument(document)
 	document.Classification = &result.Classification
+	document.Summary = result.Summary
 	return document, nil
 }
 
@@ -0,0 +1,283 @@
+package classifiers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"path/filepath"
+	"relatorios/interfaces"
+	"relatorios/models"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	vectorDimensions     = 1 << 18
+	minGramLength        = 3
+	maxGramLength        = 5
+	maxVectorizedRune    = 20000
+	defaultMinSimilarity = 0.2
+)
+
+type exemplar struct {
+	Type     string             `json:"type"`
+	Filename string             `json:"filename"`
+	TextHash string             `json:"textHash"`
+	Vector   map[uint32]float64 `json:"vector"`
+}
+
+type NearestNeighborClassifier struct {
+	modelFile     string
+	exemplars     []exemplar
+	fallback      interfaces.DocumentClassifier
+	minSimilarity float64
+	loadErr       error
+}
+
+func NewNearestNeighborClassifier(modelFile string, fallback interfaces.DocumentClassifier) *NearestNeighborClassifier {
+	classifier := &NearestNeighborClassifier{
+		modelFile:     modelFile,
+		exemplars:     []exemplar{},
+		fallback:      fallback,
+		minSimilarity: defaultMinSimilarity,
+	}
+
+	if err := classifier.loadModel(); err != nil {
+		classifier.loadErr = err
+		fmt.Printf("WARNING: %v\n", err)
+	}
+
+	return classifier
+}
+
+func (c *NearestNeighborClassifier) Classify(document models.DocumentMetadata) (models.DocumentMetadata, error) {
+	if len(c.exemplars) == 0 || strings.TrimSpace(document.Text) == "" {
+		return c.classifyWithFallback(document)
+	}
+
+	vector := vectorize(document.Text)
+
+	bestByType := make(map[string]float64)
+	nearestByType := make(map[string]string)
+
+	for _, example := range c.exemplars {
+		similarity := cosineSimilarity(vector, example.Vector)
+		if current, exists := bestByType[example.Type]; !exists || similarity > current {
+			bestByType[example.Type] = similarity
+			nearestByType[example.Type] = example.Filename
+		}
+	}
+
+	candidates := make([]models.TypeCandidate, 0, len(bestByType))
+	for documentType, similarity := range bestByType {
+		candidates = append(candidates, models.TypeCandidate{
+			DocumentType: documentType,
+			Score:        int(math.Round(similarity * 100)),
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].DocumentType < candidates[j].DocumentType
+	})
+
+	bestType := candidates[0].DocumentType
+	bestSimilarity := bestByType[bestType]
+
+	if bestSimilarity < c.minSimilarity {
+		return c.classifyWithFallback(document)
+	}
+
+	secondSimilarity := 0.0
+	if len(candidates) > 1 {
+		secondSimilarity = bestByType[candidates[1].DocumentType]
+	}
+
+	if len(candidates) > 3 {
+		candidates = candidates[:3]
+	}
+
+	document.Classification = &models.DocumentClassification{
+		DocumentType:   bestType,
+		Keywords:       []string{},
+		Confidence:     bestSimilarity * (bestSimilarity / (bestSimilarity + secondSimilarity)),
+		Candidates:     candidates,
+		Similarity:     bestSimilarity,
+		NearestExample: nearestByType[bestType],
+	}
+
+	return document, nil
+}
+
+func (c *NearestNeighborClassifier) Train(documents []models.DocumentMetadata) error {
+	if c.loadErr != nil {
+		return fmt.Errorf("exemplar model was not loaded, refusing to overwrite it: %w", c.loadErr)
+	}
+
+	positions := make(map[string]int)
+	for i, example := range c.exemplars {
+		positions[example.TextHash] = i
+	}
+
+	for _, document := range documents {
+		if document.Classification == nil || strings.TrimSpace(document.Text) == "" {
+			continue
+		}
+
+		sum := sha256.Sum256([]byte(document.Text))
+		example := exemplar{
+			Type:     document.Classification.DocumentType,
+			Filename: document.Filename,
+			TextHash: hex.EncodeToString(sum[:]),
+			Vector:   vectorize(document.Text),
+		}
+
+		if position, exists := positions[example.TextHash]; exists {
+			c.exemplars[position] = example
+			continue
+		}
+
+		positions[example.TextHash] = len(c.exemplars)
+		c.exemplars = append(c.exemplars, example)
+	}
+
+	return c.saveModel()
+}
+
+func (c *NearestNeighborClassifier) GetExemplarCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, example := range c.exemplars {
+		counts[example.Type]++
+	}
+	return counts
+}
+
+func (c *NearestNeighborClassifier) GetClassifierName() string {
+	return "Nearest Neighbour Classifier"
+}
+
+func (c *NearestNeighborClassifier) GetAnalyzeService() interfaces.AnalyzeService {
+	if provider, ok := c.fallback.(interfaces.AnalyzeServiceProvider); ok {
+		return provider.GetAnalyzeService()
+	}
+	return nil
+}
+
+func (c *NearestNeighborClassifier) classifyWithFallback(document models.DocumentMetadata) (models.DocumentMetadata, error) {
+	if c.fallback == nil {
+		document.Classification = &models.DocumentClassification{
+			DocumentType: "Other",
+			Keywords:     []string{},
+		}
+		return document, nil
+	}
+	return c.fallback.Classify(document)
+}
+
+func (c *NearestNeighborClassifier) loadModel() error {
+	data, err := os.ReadFile(c.modelFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read exemplar model: %w", err)
+	}
+
+	var exemplars []exemplar
+	if err := json.Unmarshal(data, &exemplars); err != nil {
+		return fmt.Errorf("failed to decode exemplar model %s: %w", c.modelFile, err)
+	}
+
+	c.exemplars = exemplars
+	return nil
+}
+
+func (c *NearestNeighborClassifier) saveModel() error {
+	if err := os.MkdirAll(filepath.Dir(c.modelFile), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for exemplar model: %w", err)
+	}
+
+	data, err := json.Marshal(c.exemplars)
+	if err != nil {
+		return fmt.Errorf("failed to encode exemplar model: %w", err)
+	}
+
+	if err := os.WriteFile(c.modelFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to save exemplar model: %w", err)
+	}
+
+	return nil
+}
+
+func vectorize(text string) map[uint32]float64 {
+	var builder strings.Builder
+	builder.WriteRune(' ')
+
+	lastWasSpace := true
+	count := 0
+	for _, r := range strings.ToLower(text) {
+		if count >= maxVectorizedRune {
+			break
+		}
+		count++
+
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+			lastWasSpace = false
+		} else if !lastWasSpace {
+			builder.WriteRune(' ')
+			lastWasSpace = true
+		}
+	}
+
+	if !lastWasSpace {
+		builder.WriteRune(' ')
+	}
+
+	runes := []rune(builder.String())
+	counts := make(map[uint32]float64)
+
+	for n := minGramLength; n <= maxGramLength; n++ {
+		for i := 0; i+n <= len(runes); i++ {
+			hasher := fnv.New32a()
+			hasher.Write([]byte(string(runes[i : i+n])))
+			counts[hasher.Sum32()%vectorDimensions]++
+		}
+	}
+
+	norm := 0.0
+	for index, value := range counts {
+		weight := 1 + math.Log(value)
+		counts[index] = weight
+		norm += weight * weight
+	}
+
+	norm = math.Sqrt(norm)
+	if norm > 0 {
+		for index := range counts {
+			counts[index] /= norm
+		}
+	}
+
+	return counts
+}
+
+func cosineSimilarity(a, b map[uint32]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	similarity := 0.0
+	for index, value := range a {
+		similarity += value * b[index]
+	}
+
+	return similarity
+}
@@ -0,0 +1,151 @@
+package classifiers
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"testing"
+)
+
+type stubClassifier struct{}
+
+func (c *stubClassifier) Classify(document models.DocumentMetadata) (models.DocumentMetadata, error) {
+	document.Classification = &models.DocumentClassification{DocumentType: "Fallback"}
+	return document, nil
+}
+
+func (c *stubClassifier) GetClassifierName() string {
+	return "Stub Classifier"
+}
+
+func labeled(filename, documentType, text string) models.DocumentMetadata {
+	return models.DocumentMetadata{
+		Filename:       filename,
+		Text:           text,
+		Classification: &models.DocumentClassification{DocumentType: documentType},
+	}
+}
+
+var trainingDocuments = []models.DocumentMetadata{
+	labeled("nota1.txt", "Invoice", "Nota fiscal eletronica de venda de mercadorias, valor total dos produtos R$ 1.500,00, ICMS destacado"),
+	labeled("nota2.txt", "Invoice", "Nota fiscal eletronica de servico, valor total da nota R$ 800,00, ISS retido"),
+	labeled("contrato1.txt", "Contract", "Contrato de prestacao de servicos entre contratante e contratada, clausula primeira do objeto"),
+	labeled("contrato2.txt", "Contract", "Contrato de locacao residencial, o locador e o locatario acordam a clausula de reajuste"),
+}
+
+func newTrainedClassifier(t *testing.T) (*NearestNeighborClassifier, string) {
+	t.Helper()
+	modelFile := filepath.Join(t.TempDir(), "model", "exemplars.json")
+	classifier := NewNearestNeighborClassifier(modelFile, &stubClassifier{})
+	if err := classifier.Train(trainingDocuments); err != nil {
+		t.Fatalf("Train: %v", err)
+	}
+	return classifier, modelFile
+}
+
+func TestNearestNeighborClassify(t *testing.T) {
+	classifier, _ := newTrainedClassifier(t)
+
+	tests := []struct {
+		name        string
+		text        string
+		wantType    string
+		wantNearest string
+	}{
+		{"invoice", "Nota fiscal eletronica de venda, valor total dos produtos R$ 320,00", "Invoice", "nota1.txt"},
+		{"contract", "Contrato de prestacao de servicos, a contratante e a contratada firmam a clausula", "Contract", "contrato1.txt"},
+		{"unrelated text", "xyzzy plugh qwfp", "Fallback", ""},
+		{"blank text", "  \n ", "Fallback", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			document, err := classifier.Classify(models.DocumentMetadata{Text: test.text})
+			if err != nil {
+				t.Fatalf("Classify: %v", err)
+			}
+
+			classification := document.Classification
+			if classification.DocumentType != test.wantType || classification.NearestExample != test.wantNearest {
+				t.Fatalf("classification = %+v, want %s near %q", classification, test.wantType, test.wantNearest)
+			}
+			if test.wantNearest == "" {
+				return
+			}
+
+			if classification.Confidence <= 0 || classification.Confidence > classification.Similarity {
+				t.Errorf("confidence %v is not within (0, similarity %v]", classification.Confidence, classification.Similarity)
+			}
+			if len(classification.Candidates) != 2 || classification.Candidates[0].DocumentType != test.wantType ||
+				classification.Candidates[0].Score < classification.Candidates[1].Score {
+				t.Errorf("candidates = %+v", classification.Candidates)
+			}
+		})
+	}
+}
+
+func TestNearestNeighborClassifyWithoutExemplars(t *testing.T) {
+	classifier := NewNearestNeighborClassifier(filepath.Join(t.TempDir(), "exemplars.json"), nil)
+
+	document, err := classifier.Classify(models.DocumentMetadata{Text: "Nota fiscal eletronica"})
+	if err != nil {
+		t.Fatalf("Classify: %v", err)
+	}
+	if document.Classification.DocumentType != "Other" {
+		t.Errorf("type = %q, want Other", document.Classification.DocumentType)
+	}
+}
+
+func TestNearestNeighborTrain(t *testing.T) {
+	classifier, _ := newTrainedClassifier(t)
+
+	err := classifier.Train([]models.DocumentMetadata{
+		labeled("nota1-copia.txt", "Receipt", trainingDocuments[0].Text),
+		{Filename: "sem-rotulo.txt", Text: "Texto sem rotulo"},
+		labeled("vazio.txt", "Invoice", "   "),
+	})
+	if err != nil {
+		t.Fatalf("Train: %v", err)
+	}
+
+	counts := classifier.GetExemplarCounts()
+	if counts["Invoice"] != 1 || counts["Receipt"] != 1 || counts["Contract"] != 2 || len(classifier.exemplars) != 4 {
+		t.Errorf("exemplar counts = %v, want the duplicate text relabeled and the rest skipped", counts)
+	}
+}
+
+func TestNearestNeighborModelRoundTrip(t *testing.T) {
+	trained, modelFile := newTrainedClassifier(t)
+
+	loaded := NewNearestNeighborClassifier(modelFile, &stubClassifier{})
+	if len(loaded.exemplars) != len(trained.exemplars) {
+		t.Fatalf("loaded %d exemplars, want %d", len(loaded.exemplars), len(trained.exemplars))
+	}
+
+	document := models.DocumentMetadata{Text: "Contrato de locacao, o locatario aceita a clausula de reajuste"}
+	want, _ := trained.Classify(document)
+	got, _ := loaded.Classify(document)
+	if got.Classification.DocumentType != want.Classification.DocumentType ||
+		got.Classification.NearestExample != want.Classification.NearestExample ||
+		math.Abs(got.Classification.Similarity-want.Classification.Similarity) > 1e-9 {
+		t.Errorf("loaded classification = %+v, want %+v", got.Classification, want.Classification)
+	}
+}
+
+func TestNearestNeighborKeepsCorruptModel(t *testing.T) {
+	modelFile := filepath.Join(t.TempDir(), "exemplars.json")
+	corrupt := []byte(`[{"type": "Invoice", "vector": {`)
+	if err := os.WriteFile(modelFile, corrupt, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	classifier := NewNearestNeighborClassifier(modelFile, &stubClassifier{})
+	if err := classifier.Train(trainingDocuments); err == nil {
+		t.Error("Train overwrote a model that could not be read")
+	}
+
+	if data, err := os.ReadFile(modelFile); err != nil || string(data) != string(corrupt) {
+		t.Errorf("model file = %q, %v; want it untouched", data, err)
+	}
+}
@@ -1,20 +1,46 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"relatorios/interfaces"
 	"relatorios/models"
-	"relatorios/services/classifiers"
 	"relatorios/services/extractors"
+	"relatorios/services/language"
+	"strings"
+	"time"
+)
+
+const (
+	stagingDirectory  = "staging"
+	spillDirectory    = ".spill"
+	maxEmbeddingDepth = 5
 )
 
 type DocumentProcessingService struct {
 	extractorFactory *extractors.DocumentExtractorFactory
 	classifier       interfaces.DocumentClassifier
+	classifiers      []interfaces.DocumentClassifier
 	config           models.ProcessingConfig
+	reviewQueue      *ReviewQueueService
+	trainingCache    map[string]models.DocumentMetadata
+	ocrOptionsCache  map[string]folderOCROptions
+}
+
+type folderOCROptions struct {
+	ruleSetFile  string
+	ruleSetStamp fileStamp
+	folderStamp  fileStamp
+	options      models.OCROptions
+	warnings     []models.ExtractionWarning
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
 }
 
 func NewDocumentProcessingService(
@@ -22,43 +48,252 @@ func NewDocumentProcessingService(
 	classifier interfaces.DocumentClassifier,
 	config models.ProcessingConfig,
 ) *DocumentProcessingService {
-	return &DocumentProcessingService{
+	service := &DocumentProcessingService{
 		extractorFactory: extractorFactory,
 		classifier:       classifier,
+		classifiers:      []interfaces.DocumentClassifier{classifier},
 		config:           config,
 	}
+
+	if config.ReviewQueueFile != "" {
+		service.reviewQueue = NewReviewQueueService(config.ReviewQueueFile, config.LabeledExamplesFile)
+	}
+
+	return service
 }
 
 func (s *DocumentProcessingService) GetClassifierName() string {
 	return s.classifier.GetClassifierName()
 }
 
+func (s *DocumentProcessingService) AddClassifier(classifier interfaces.DocumentClassifier) {
+	s.classifiers = append(s.classifiers, classifier)
+}
+
+func (s *DocumentProcessingService) GetClassifiers() []interfaces.DocumentClassifier {
+	return s.classifiers
+}
+
+func (s *DocumentProcessingService) SelectClassifier(name string) error {
+	for _, classifier := range s.classifiers {
+		if classifier.GetClassifierName() == name {
+			s.classifier = classifier
+			return nil
+		}
+	}
+	return fmt.Errorf("classifier not found: %s", name)
+}
+
+func (s *DocumentProcessingService) TrainClassifierFromFolders(samplesDir string) (int, error) {
+	trainer, ok := s.classifier.(interfaces.TrainableClassifier)
+	if !ok {
+		return 0, fmt.Errorf("classifier %s does not support training", s.classifier.GetClassifierName())
+	}
+
+	documents, err := s.BuildLabeledIndex(samplesDir)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(documents) == 0 {
+		return 0, fmt.Errorf("no supported documents found in sample folders")
+	}
+
+	if err := trainer.Train(documents); err != nil {
+		return 0, err
+	}
+
+	return len(documents), nil
+}
+
 func (s *DocumentProcessingService) GetSupportedFormats() []string {
 	return s.extractorFactory.GetSupportedFormats()
 }
 
+func (s *DocumentProcessingService) IsFormatSupported(filePath string) bool {
+	return s.extractorFactory.IsFormatSupported(filePath)
+}
+
+func (s *DocumentProcessingService) DetectFormat(filePath string) extractors.ContentFormat {
+	return s.extractorFactory.DetectFormat(filePath)
+}
+
 func (s *DocumentProcessingService) GetOutputDirectory() string {
 	return s.config.OutputDirectory
 }
 
-func (s *DocumentProcessingService) ProcessSingleFile(filePath string) (models.DocumentMetadata, string, error) {
-	if !s.extractorFactory.IsFormatSupported(filePath) {
-		return models.DocumentMetadata{}, "", fmt.Errorf("unsupported format: %s", filepath.Ext(filePath))
+func (s *DocumentProcessingService) ExtractDocument(filePath string) (models.DocumentMetadata, error) {
+	return s.extractDocument(filePath, filepath.Dir(filePath), s.extractorFactory.DetectFormat(filePath))
+}
+
+func (s *DocumentProcessingService) extractDocument(filePath string, sourceDir string, format extractors.ContentFormat) (models.DocumentMetadata, error) {
+	if !s.extractorFactory.HasExtractor(format) {
+		return models.DocumentMetadata{}, fmt.Errorf("unsupported format: %s", filepath.Ext(filePath))
 	}
 
-	extractor, err := s.extractorFactory.GetExtractorForFile(filePath)
+	ocrOptions, ocrWarnings := s.ocrOptions(sourceDir)
+
+	document, err := s.extractorFactory.ExtractDocument(filePath, format, ocrOptions)
 	if err != nil {
-		return models.DocumentMetadata{}, "", err
+		return models.DocumentMetadata{}, err
 	}
+	document.Warnings = append(append([]models.ExtractionWarning{}, ocrWarnings...), document.Warnings...)
 
-	document, err := extractor.ExtractText(filePath)
+	if document.Language == "" {
+		document.Language = language.Detect(document.Text)
+	}
+
+	if format.Mismatch() {
+		if document.Metadata == nil {
+			document.Metadata = make(map[string]string)
+		}
+		document.Metadata["formatWarning"] = fmt.Sprintf("file extension %s does not match its content (%s)", format.Extension, format.Detected)
+	}
+
+	return document, nil
+}
+
+func (s *DocumentProcessingService) ocrOptions(sourceDir string) (models.OCROptions, []models.ExtractionWarning) {
+	ruleSetFile := ""
+	if analyzeService := s.GetAnalyzeService(); analyzeService != nil {
+		ruleSetFile = models.RuleSetOCROptionsFile(analyzeService.GetRulesFilePath())
+	}
+	folderFile := filepath.Join(sourceDir, models.FolderOCROptionsFile)
+
+	entry := folderOCROptions{
+		ruleSetFile:  ruleSetFile,
+		ruleSetStamp: statFile(ruleSetFile),
+		folderStamp:  statFile(folderFile),
+	}
+	if cached, ok := s.ocrOptionsCache[sourceDir]; ok &&
+		cached.ruleSetFile == entry.ruleSetFile &&
+		cached.ruleSetStamp == entry.ruleSetStamp &&
+		cached.folderStamp == entry.folderStamp {
+		return cached.options, cached.warnings
+	}
+
+	entry.options = s.config.OCR
+	if ruleSetFile != "" {
+		ruleSetOptions, err := models.LoadOCROptionsFromJSON(ruleSetFile)
+		if err != nil {
+			entry.warnings = append(entry.warnings, models.ExtractionWarning{Source: "ocr", Message: err.Error()})
+		}
+		entry.options = entry.options.Merge(ruleSetOptions)
+	}
+
+	folderOptions, err := models.LoadOCROptionsFromJSON(folderFile)
 	if err != nil {
-		return models.DocumentMetadata{}, "", fmt.Errorf("failed to extract text: %w", err)
+		entry.warnings = append(entry.warnings, models.ExtractionWarning{Source: "ocr", Message: err.Error()})
 	}
+	entry.options = entry.options.Merge(folderOptions)
 
-	document, err = s.classifier.Classify(document)
+	if s.ocrOptionsCache == nil {
+		s.ocrOptionsCache = make(map[string]folderOCROptions)
+	}
+	s.ocrOptionsCache[sourceDir] = entry
+
+	return entry.options, entry.warnings
+}
+
+func statFile(path string) fileStamp {
+	if path == "" {
+		return fileStamp{}
+	}
+
+	info, err := os.Stat(path)
 	if err != nil {
-		return models.DocumentMetadata{}, "", fmt.Errorf("classification failed: %w", err)
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+func (s *DocumentProcessingService) BuildTextIndex(dirPath string) (*models.TextIndex, error) {
+	fileInfo, err := os.Stat(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("error accessing directory: %w", err)
+	}
+
+	if !fileInfo.IsDir() {
+		return nil, fmt.Errorf("provided path is not a directory")
+	}
+
+	files, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("error listing files in directory: %w", err)
+	}
+
+	index := &models.TextIndex{
+		Source:    dirPath,
+		Documents: make([]models.DocumentMetadata, 0),
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		filePath := filepath.Join(dirPath, file.Name())
+		format := s.extractorFactory.DetectFormat(filePath)
+		if !s.extractorFactory.HasExtractor(format) {
+			continue
+		}
+
+		document, err := s.extractDocument(filePath, dirPath, format)
+		if err != nil {
+			continue
+		}
+
+		index.Documents = append(index.Documents, document)
+	}
+
+	return index, nil
+}
+
+func (s *DocumentProcessingService) BuildLabeledIndex(samplesDir string) ([]models.DocumentMetadata, error) {
+	entries, err := os.ReadDir(samplesDir)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sample folders: %w", err)
+	}
+
+	documents := make([]models.DocumentMetadata, 0)
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		index, err := s.BuildTextIndex(filepath.Join(samplesDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, document := range index.Documents {
+			document.Classification = &models.DocumentClassification{
+				DocumentType: entry.Name(),
+			}
+			documents = append(documents, document)
+		}
+	}
+
+	return documents, nil
+}
+
+func (s *DocumentProcessingService) ProcessSingleFile(filePath string, format extractors.ContentFormat) (models.DocumentMetadata, string, error) {
+	return s.processFile(filePath, filepath.Dir(filePath), format)
+}
+
+func (s *DocumentProcessingService) processFile(filePath string, sourceDir string, format extractors.ContentFormat) (models.DocumentMetadata, string, error) {
+	document, err := s.classifyFile(filePath, sourceDir, format)
+	if err != nil {
+		return models.DocumentMetadata{}, "", err
+	}
+
+	if s.reviewQueue != nil && document.Classification.Confidence < s.config.ReviewThreshold {
+		document.Classification.NeedsReview = true
+		if err := s.reviewQueue.Enqueue(filePath, document); err != nil {
+			return document, "", fmt.Errorf("failed to queue document for review: %w", err)
+		}
+		return document, "", nil
 	}
 
 	destinationPath, err := s.organizeFile(filePath, document.Classification.DocumentType)
@@ -69,6 +304,97 @@ func (s *DocumentProcessingService) ProcessSingleFile(filePath string) (models.D
 	return document, destinationPath, nil
 }
 
+func (s *DocumentProcessingService) classifyFile(filePath string, sourceDir string, format extractors.ContentFormat) (models.DocumentMetadata, error) {
+	document, err := s.extractDocument(filePath, sourceDir, format)
+	if err != nil {
+		return models.DocumentMetadata{}, err
+	}
+
+	document, err = s.classifier.Classify(document)
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("classification failed: %w", err)
+	}
+
+	if document.Summary == "" {
+		document.Summary = summarize(document.Text, document.Classification.Keywords)
+	}
+
+	return document, nil
+}
+
+func (s *DocumentProcessingService) IsArchiveFormat(format extractors.ContentFormat) bool {
+	return s.extractorFactory.IsArchiveFormat(format)
+}
+
+func (s *DocumentProcessingService) ProcessArchive(filePath string, format extractors.ContentFormat) []models.FileProcessingResult {
+	archiveName := filepath.Base(filePath)
+	stagingDir, err := s.createStagingDir(filePath)
+	if err != nil {
+		return []models.FileProcessingResult{{
+			Filename: archiveName,
+			Success:  false,
+			Error:    err.Error(),
+		}}
+	}
+	budget := extractors.NewArchiveBudget(filepath.Join(stagingDir, spillDirectory))
+
+	entries, err := s.extractorFactory.ReadArchive(filePath, format, budget)
+	if err != nil {
+		removeStagingDir(stagingDir)
+		return []models.FileProcessingResult{{
+			Filename: archiveName,
+			Success:  false,
+			Error:    err.Error(),
+		}}
+	}
+
+	copyArchive := s.config.ArchivePolicy == models.ArchivePolicyCopy
+	results := s.processEmbeddedFiles(filePath, stagingDir, entries, !copyArchive, budget)
+
+	if !copyArchive {
+		return results
+	}
+
+	typeCounts := make(map[string]int)
+	archiveType := ""
+	entryCount := 0
+	for _, result := range results {
+		if !result.Skipped {
+			entryCount++
+		}
+		if !result.Success {
+			continue
+		}
+		typeCounts[result.DocumentType]++
+		if archiveType == "" || typeCounts[result.DocumentType] > typeCounts[archiveType] {
+			archiveType = result.DocumentType
+		}
+	}
+
+	if archiveType == "" {
+		return append([]models.FileProcessingResult{{
+			Filename: archiveName,
+			Success:  false,
+			Error:    "No entry could be classified",
+		}}, results...)
+	}
+
+	archiveResult := models.FileProcessingResult{
+		Filename:     archiveName,
+		Success:      true,
+		DocumentType: archiveType,
+		Confidence:   float64(typeCounts[archiveType]) / float64(entryCount),
+		Summary:      fmt.Sprintf("Archive with %d entries, %d classified as %s", entryCount, typeCounts[archiveType], archiveType),
+	}
+
+	if _, err := s.organizeFile(filePath, archiveType); err != nil {
+		archiveResult.Success = false
+		archiveResult.Error = err.Error()
+	}
+
+	return append([]models.FileProcessingResult{archiveResult}, results...)
+}
+
 func (s *DocumentProcessingService) ProcessDirectory(dirPath string) (*models.ProcessingResult, error) {
 	fileInfo, err := os.Stat(dirPath)
 	if err != nil {
@@ -95,9 +421,16 @@ func (s *DocumentProcessingService) ProcessDirectory(dirPath string) (*models.Pr
 
 		filePath := filepath.Join(dirPath, file.Name())
 
-		if !s.extractorFactory.IsFormatSupported(filePath) {
-			result.FailedCount++
-			result.Results = append(result.Results, models.FileProcessingResult{
+		format := s.extractorFactory.DetectFormat(filePath)
+		if s.extractorFactory.IsArchiveFormat(format) {
+			for _, archiveResult := range s.ProcessArchive(filePath, format) {
+				result.Add(archiveResult)
+			}
+			continue
+		}
+
+		if !s.extractorFactory.SupportsFormat(format) {
+			result.Add(models.FileProcessingResult{
 				Filename: file.Name(),
 				Success:  false,
 				Error:    "Unsupported format",
@@ -105,25 +438,444 @@ func (s *DocumentProcessingService) ProcessDirectory(dirPath string) (*models.Pr
 			continue
 		}
 
-		document, _, err := s.ProcessSingleFile(filePath)
-		if err != nil {
-			result.FailedCount++
-			result.Results = append(result.Results, models.FileProcessingResult{
-				Filename: file.Name(),
+		document, _, err := s.ProcessSingleFile(filePath, format)
+		result.Add(s.fileResult(file.Name(), document, err))
+
+		if err == nil {
+			for _, attachmentResult := range s.ProcessAttachments(filePath, document) {
+				result.Add(attachmentResult)
+			}
+		}
+	}
+
+	reportPath, err := s.writeReport(result)
+	if err != nil {
+		return result, err
+	}
+	result.ReportPath = reportPath
+
+	return result, nil
+}
+
+func (s *DocumentProcessingService) ProcessAttachments(filePath string, document models.DocumentMetadata) []models.FileProcessingResult {
+	if len(document.Attachments) == 0 {
+		return nil
+	}
+
+	stagingDir, err := s.createStagingDir(filePath)
+	if err != nil {
+		return []models.FileProcessingResult{{
+			Filename: filepath.Base(filePath),
+			Success:  false,
+			Error:    err.Error(),
+		}}
+	}
+	budget := extractors.NewArchiveBudget(filepath.Join(stagingDir, spillDirectory))
+	return s.processEmbeddedFiles(filePath, stagingDir, document.Attachments, true, budget)
+}
+
+func (s *DocumentProcessingService) processEmbeddedFiles(containerPath string, stagingDir string, files []models.EmbeddedFile, organize bool, budget *extractors.ArchiveBudget) []models.FileProcessingResult {
+	usedNames := map[string]bool{spillDirectory: true}
+
+	results := s.processEmbeddedLevel(filepath.Base(containerPath), stagingDir, filepath.Dir(containerPath), files, usedNames, organize, budget, 1)
+
+	removeStagingDir(stagingDir)
+
+	return results
+}
+
+func (s *DocumentProcessingService) createStagingDir(containerPath string) (string, error) {
+	stagingRoot := filepath.Join(s.config.OutputDirectory, stagingDirectory)
+	if err := os.MkdirAll(stagingRoot, 0755); err != nil {
+		return "", fmt.Errorf("error creating staging directory: %w", err)
+	}
+
+	stagingDir, err := os.MkdirTemp(stagingRoot, filepath.Base(containerPath)+"-*")
+	if err != nil {
+		return "", fmt.Errorf("error creating staging directory: %w", err)
+	}
+	return stagingDir, nil
+}
+
+func removeStagingDir(stagingDir string) {
+	_ = os.RemoveAll(filepath.Join(stagingDir, spillDirectory))
+	if os.Remove(stagingDir) == nil {
+		_ = os.Remove(filepath.Dir(stagingDir))
+	}
+}
+
+func (s *DocumentProcessingService) processEmbeddedLevel(parentName string, stagingDir string, sourceDir string, files []models.EmbeddedFile, usedNames map[string]bool, organize bool, budget *extractors.ArchiveBudget, depth int) []models.FileProcessingResult {
+	results := make([]models.FileProcessingResult, 0, len(files))
+
+	if depth > maxEmbeddingDepth {
+		return append(results, models.FileProcessingResult{
+			Filename: parentName,
+			Success:  false,
+			Error:    "Embedded files nested too deeply",
+		})
+	}
+
+	if err := os.MkdirAll(stagingDir, 0755); err != nil {
+		return append(results, models.FileProcessingResult{
+			Filename: parentName,
+			Success:  false,
+			Error:    fmt.Sprintf("error creating staging directory: %v", err),
+		})
+	}
+
+	for _, file := range files {
+		displayName := parentName + "!/" + file.Name
+
+		if file.Error != "" {
+			results = append(results, models.FileProcessingResult{
+				Filename: displayName,
 				Success:  false,
-				Error:    err.Error(),
+				Error:    file.Error,
 			})
-		} else {
-			result.ProcessedCount++
-			result.Results = append(result.Results, models.FileProcessingResult{
-				Filename:     file.Name(),
-				Success:      true,
-				DocumentType: document.Classification.DocumentType,
+			continue
+		}
+
+		stagedPath := filepath.Join(stagingDir, uniqueStagedName(file.Name, usedNames))
+		if err := stageEmbeddedFile(file, stagedPath); err != nil {
+			results = append(results, models.FileProcessingResult{
+				Filename: displayName,
+				Success:  false,
+				Error:    fmt.Sprintf("error staging file: %v", err),
 			})
+			continue
+		}
+
+		format := s.extractorFactory.DetectFormat(stagedPath)
+		isArchive := s.extractorFactory.IsArchiveFormat(format)
+		if !s.extractorFactory.SupportsFormat(format) {
+			_ = os.Remove(stagedPath)
+			results = append(results, models.FileProcessingResult{
+				Filename: displayName,
+				Skipped:  true,
+				Warning:  "Unsupported format",
+			})
+			continue
+		}
+
+		if isArchive {
+			entries, err := s.extractorFactory.ReadArchive(stagedPath, format, budget)
+			_ = os.Remove(stagedPath)
+			if err != nil {
+				results = append(results, s.fileResult(displayName, models.DocumentMetadata{}, err))
+				continue
+			}
+
+			results = append(results, s.processEmbeddedLevel(displayName, stagingDir, sourceDir, entries, usedNames, organize, budget, depth+1)...)
+			continue
+		}
+
+		var document models.DocumentMetadata
+		var err error
+		if organize {
+			document, _, err = s.processFile(stagedPath, sourceDir, format)
+		} else {
+			document, err = s.classifyFile(stagedPath, sourceDir, format)
+		}
+
+		results = append(results, s.fileResult(displayName, document, err))
+		if err != nil || !document.Classification.NeedsReview {
+			_ = os.Remove(stagedPath)
+		}
+		if err != nil {
+			continue
 		}
+
+		results = append(results, s.processEmbeddedLevel(displayName, stagingDir, sourceDir, document.Attachments, usedNames, organize, budget, depth+1)...)
 	}
 
-	return result, nil
+	return results
+}
+
+func (s *DocumentProcessingService) fileResult(filename string, document models.DocumentMetadata, err error) models.FileProcessingResult {
+	if err != nil {
+		return models.FileProcessingResult{
+			Filename: filename,
+			Success:  false,
+			Error:    err.Error(),
+		}
+	}
+
+	return models.FileProcessingResult{
+		Filename:      filename,
+		Success:       true,
+		DocumentType:  document.Classification.DocumentType,
+		Confidence:    document.Classification.Confidence,
+		PendingReview: document.Classification.NeedsReview,
+		Summary:       document.Summary,
+		Warning:       documentWarnings(document),
+		Info:          document.Info,
+	}
+}
+
+func documentWarnings(document models.DocumentMetadata) string {
+	messages := []string{}
+	if formatWarning := document.Metadata["formatWarning"]; formatWarning != "" {
+		messages = append(messages, formatWarning)
+	}
+
+	for _, warning := range document.Warnings {
+		if warning.Page > 0 {
+			messages = append(messages, fmt.Sprintf("%s (page %d): %s", warning.Source, warning.Page, warning.Message))
+			continue
+		}
+		messages = append(messages, warning.Source+": "+warning.Message)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func stageEmbeddedFile(file models.EmbeddedFile, stagedPath string) error {
+	if file.Path != "" {
+		return os.Rename(file.Path, stagedPath)
+	}
+	return os.WriteFile(stagedPath, file.Data, 0644)
+}
+
+func uniqueStagedName(name string, usedNames map[string]bool) string {
+	name = strings.TrimSpace(name[strings.LastIndexAny(name, "/\\")+1:])
+	if name == "" || name == "." || name == ".." {
+		name = "attachment"
+	}
+
+	extension := filepath.Ext(name)
+	base := strings.TrimSuffix(name, extension)
+
+	candidate := name
+	for i := 2; usedNames[strings.ToLower(candidate)]; i++ {
+		candidate = fmt.Sprintf("%s (%d)%s", base, i, extension)
+	}
+	usedNames[strings.ToLower(candidate)] = true
+
+	return candidate
+}
+
+func availablePath(directory string, name string) string {
+	extension := filepath.Ext(name)
+	base := strings.TrimSuffix(name, extension)
+
+	candidate := filepath.Join(directory, name)
+	for i := 2; ; i++ {
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+		candidate = filepath.Join(directory, fmt.Sprintf("%s (%d)%s", base, i, extension))
+	}
+}
+
+func (s *DocumentProcessingService) writeReport(result *models.ProcessingResult) (string, error) {
+	if err := os.MkdirAll(s.config.OutputDirectory, 0755); err != nil {
+		return "", fmt.Errorf("error creating output directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error encoding processing report: %w", err)
+	}
+
+	reportPath := filepath.Join(s.config.OutputDirectory, "processing_report.json")
+	if err := os.WriteFile(reportPath, data, 0644); err != nil {
+		return "", fmt.Errorf("error writing processing report: %w", err)
+	}
+
+	return reportPath, nil
+}
+
+func (s *DocumentProcessingService) GetPendingReviews() ([]models.ReviewItem, error) {
+	if s.reviewQueue == nil {
+		return []models.ReviewItem{}, nil
+	}
+	return s.reviewQueue.GetPending()
+}
+
+func (s *DocumentProcessingService) ResolveReview(item models.ReviewItem, documentType string) (string, error) {
+	if s.reviewQueue == nil {
+		return "", fmt.Errorf("review queue is not configured")
+	}
+	if err := models.ValidateDocumentType(documentType); err != nil {
+		return "", err
+	}
+
+	destinationPath, err := s.organizeFile(item.SourcePath, documentType)
+	if err != nil {
+		return "", err
+	}
+	s.removeStagedSource(item.SourcePath)
+
+	if _, err := s.reviewQueue.Remove(item.ID); err != nil {
+		return destinationPath, err
+	}
+
+	example := models.LabeledExample{
+		Path:      destinationPath,
+		Filename:  item.Filename,
+		TextHash:  item.TextHash,
+		Predicted: item.Classification.DocumentType,
+		Label:     documentType,
+		Source:    "review",
+	}
+	if err := s.reviewQueue.RecordExample(example); err != nil {
+		return destinationPath, fmt.Errorf("document filed but example not recorded: %w", err)
+	}
+
+	if err := s.trainIfSupported(example); err != nil {
+		return destinationPath, fmt.Errorf("document filed but retraining failed: %w", err)
+	}
+
+	return destinationPath, nil
+}
+
+func (s *DocumentProcessingService) CorrectClassification(document models.DocumentMetadata, destinationPath string, documentType string) (string, error) {
+	if s.reviewQueue == nil {
+		return "", fmt.Errorf("labeled examples are not configured")
+	}
+	if err := models.ValidateDocumentType(documentType); err != nil {
+		return "", err
+	}
+
+	destDir := filepath.Join(s.config.OutputDirectory, documentType)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", fmt.Errorf("error creating destination directory: %w", err)
+	}
+
+	correctedPath := destinationPath
+	if filepath.Clean(filepath.Dir(destinationPath)) != filepath.Clean(destDir) {
+		correctedPath = availablePath(destDir, filepath.Base(destinationPath))
+		if err := os.Rename(destinationPath, correctedPath); err != nil {
+			return "", fmt.Errorf("error moving file: %w", err)
+		}
+	}
+
+	example := models.LabeledExample{
+		Path:      correctedPath,
+		Filename:  document.Filename,
+		TextHash:  hashText(document.Text),
+		Predicted: document.Classification.DocumentType,
+		Label:     documentType,
+		Source:    "correction",
+	}
+	if err := s.reviewQueue.RecordExample(example); err != nil {
+		return correctedPath, fmt.Errorf("document moved but correction not recorded: %w", err)
+	}
+
+	s.cacheTrainingDocument(example.TextHash, document)
+	if err := s.trainIfSupported(example); err != nil {
+		return correctedPath, fmt.Errorf("document moved but retraining failed: %w", err)
+	}
+
+	return correctedPath, nil
+}
+
+func (s *DocumentProcessingService) GetLabeledExamples() ([]models.LabeledExample, error) {
+	if s.config.LabeledExamplesFile == "" {
+		return []models.LabeledExample{}, nil
+	}
+	return models.LoadLabeledExamplesFromJSON(s.config.LabeledExamplesFile)
+}
+
+func (s *DocumentProcessingService) IsClassifierTrainable() bool {
+	_, ok := s.classifier.(interfaces.TrainableClassifier)
+	return ok
+}
+
+func (s *DocumentProcessingService) RetrainClassifier() (int, error) {
+	trainer, ok := s.classifier.(interfaces.TrainableClassifier)
+	if !ok {
+		return 0, fmt.Errorf("classifier %s does not support training", s.classifier.GetClassifierName())
+	}
+
+	examples, err := s.GetLabeledExamples()
+	if err != nil {
+		return 0, err
+	}
+
+	documents := make([]models.DocumentMetadata, 0, len(examples))
+	for _, example := range examples {
+		document, err := s.trainingDocument(example)
+		if err != nil {
+			continue
+		}
+		documents = append(documents, document)
+	}
+
+	if err := trainer.Train(documents); err != nil {
+		return 0, err
+	}
+
+	return len(documents), nil
+}
+
+func (s *DocumentProcessingService) trainIfSupported(example models.LabeledExample) error {
+	trainer, ok := s.classifier.(interfaces.TrainableClassifier)
+	if !ok {
+		return nil
+	}
+
+	document, err := s.trainingDocument(example)
+	if err != nil {
+		return err
+	}
+
+	return trainer.Train([]models.DocumentMetadata{document})
+}
+
+func (s *DocumentProcessingService) trainingDocument(example models.LabeledExample) (models.DocumentMetadata, error) {
+	document, cached := s.trainingCache[example.TextHash]
+	if !cached || example.TextHash == "" {
+		extracted, err := s.ExtractDocument(example.Path)
+		if err != nil {
+			return models.DocumentMetadata{}, err
+		}
+		document = extracted
+		s.cacheTrainingDocument(example.TextHash, document)
+	}
+
+	document.Classification = &models.DocumentClassification{
+		DocumentType: example.Label,
+	}
+	return document, nil
+}
+
+func (s *DocumentProcessingService) cacheTrainingDocument(textHash string, document models.DocumentMetadata) {
+	if textHash == "" {
+		return
+	}
+	if s.trainingCache == nil {
+		s.trainingCache = make(map[string]models.DocumentMetadata)
+	}
+
+	s.trainingCache[textHash] = models.DocumentMetadata{
+		Filename: document.Filename,
+		Text:     document.Text,
+		Language: document.Language,
+		Metadata: document.Metadata,
+	}
+}
+
+func (s *DocumentProcessingService) DiscardReview(item models.ReviewItem) error {
+	if s.reviewQueue == nil {
+		return fmt.Errorf("review queue is not configured")
+	}
+
+	if _, err := s.reviewQueue.Remove(item.ID); err != nil {
+		return err
+	}
+	s.removeStagedSource(item.SourcePath)
+	return nil
+}
+
+func (s *DocumentProcessingService) removeStagedSource(sourcePath string) {
+	relative, err := filepath.Rel(filepath.Join(s.config.OutputDirectory, stagingDirectory), sourcePath)
+	if err != nil || relative == "." || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return
+	}
+
+	_ = os.Remove(sourcePath)
+	removeStagingDir(filepath.Dir(sourcePath))
 }
 
 func (s *DocumentProcessingService) organizeFile(filePath string, documentType string) (string, error) {
@@ -132,8 +884,7 @@ func (s *DocumentProcessingService) organizeFile(filePath string, documentType s
 		return "", fmt.Errorf("error creating destination directory: %w", err)
 	}
 
-	fileName := filepath.Base(filePath)
-	destPath := filepath.Join(destDir, fileName)
+	destPath := availablePath(destDir, filepath.Base(filePath))
 
 	if s.config.MoveFiles {
 		if err := os.Rename(filePath, destPath); err != nil {
@@ -161,8 +912,12 @@ func (s *DocumentProcessingService) organizeFile(filePath string, documentType s
 }
 
 func (s *DocumentProcessingService) GetAnalyzeService() interfaces.AnalyzeService {
-	if classifier, ok := s.classifier.(*classifiers.DocumentClassifier); ok {
-		return classifier.GetAnalyzeService()
+	candidates := append([]interfaces.DocumentClassifier{s.classifier}, s.classifiers...)
+
+	for _, candidate := range candidates {
+		if provider, ok := candidate.(interfaces.AnalyzeServiceProvider); ok && provider.GetAnalyzeService() != nil {
+			return provider.GetAnalyzeService()
+		}
 	}
 	return nil
 }
@@ -0,0 +1,309 @@
+package extractors
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+)
+
+const (
+	maxArchiveEntries      = 1000
+	maxArchiveEntrySize    = 100 << 20
+	maxArchiveTotalSize    = 500 << 20
+	maxCompressionRatio    = 100
+	minRatioCheckEntrySize = 1 << 20
+	maxInMemoryEntrySize   = 4 << 20
+)
+
+var unsupportedArchiveFormats = []string{".7z", ".rar"}
+
+type ArchiveReader struct{}
+
+type ArchiveBudget struct {
+	spillDir  string
+	entries   int
+	totalSize int64
+	stopped   string
+}
+
+func NewArchiveBudget(spillDir string) *ArchiveBudget {
+	return &ArchiveBudget{spillDir: spillDir}
+}
+
+func (r *ArchiveReader) IsSupportedFormat(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	for _, format := range append(r.GetSupportedFormats(), unsupportedArchiveFormats...) {
+		if ext == format {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ArchiveReader) GetSupportedFormats() []string {
+	return []string{".zip", ".tar", ".tgz", ".gz"}
+}
+
+func (r *ArchiveReader) ReadEntries(filePath string, format ContentFormat, budget *ArchiveBudget) ([]models.EmbeddedFile, error) {
+	ext := format.Format()
+
+	for _, format := range unsupportedArchiveFormats {
+		if ext == format {
+			return nil, fmt.Errorf("%s archives are not supported: extract them before processing", strings.TrimPrefix(format, "."))
+		}
+	}
+
+	if budget.stopped != "" {
+		return nil, fmt.Errorf("archive not extracted: %s", budget.stopped)
+	}
+
+	limiter := &archiveLimiter{budget: budget}
+
+	switch {
+	case ext == ".zip":
+		return r.readZip(filePath, limiter)
+	case ext == ".tar":
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer file.Close()
+
+		return r.readTar(file, limiter)
+	case ext == ".tgz":
+		return r.readGzip(filePath, true, limiter)
+	case ext == ".gz":
+		return r.readGzip(filePath, false, limiter)
+	}
+
+	return nil, fmt.Errorf("unsupported archive format: %s", ext)
+}
+
+func (r *ArchiveReader) readZip(filePath string, limiter *archiveLimiter) ([]models.EmbeddedFile, error) {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip archive: %w", err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		if !limiter.accept(file.Name, int64(file.UncompressedSize64)) {
+			if limiter.stopped {
+				break
+			}
+			continue
+		}
+
+		if file.Flags&0x1 != 0 {
+			limiter.reject(file.Name, "encrypted entry")
+			continue
+		}
+
+		if file.CompressedSize64 > 0 && file.UncompressedSize64 > minRatioCheckEntrySize &&
+			file.UncompressedSize64/file.CompressedSize64 > maxCompressionRatio {
+			limiter.reject(file.Name, fmt.Sprintf("compression ratio above %d:1", maxCompressionRatio))
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			limiter.reject(file.Name, err.Error())
+			continue
+		}
+
+		limiter.read(file.Name, rc)
+		rc.Close()
+	}
+
+	return limiter.entries, nil
+}
+
+func (r *ArchiveReader) readTar(source io.Reader, limiter *archiveLimiter) ([]models.EmbeddedFile, error) {
+	reader := tar.NewReader(source)
+
+	for !limiter.stopped {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return limiter.entries, fmt.Errorf("failed to read tar archive: %w", err)
+		}
+
+		if !header.FileInfo().Mode().IsRegular() {
+			continue
+		}
+
+		if limiter.accept(header.Name, header.Size) {
+			limiter.read(header.Name, reader)
+		}
+	}
+
+	return limiter.entries, nil
+}
+
+func (r *ArchiveReader) readGzip(filePath string, isTar bool, limiter *archiveLimiter) ([]models.EmbeddedFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip archive: %w", err)
+	}
+	defer reader.Close()
+
+	if isTar {
+		return r.readTar(reader, limiter)
+	}
+
+	name := reader.Name
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	}
+
+	if limiter.accept(name, 0) {
+		limiter.read(name, reader)
+	}
+
+	return limiter.entries, nil
+}
+
+type archiveLimiter struct {
+	budget  *ArchiveBudget
+	entries []models.EmbeddedFile
+	stopped bool
+}
+
+func (l *archiveLimiter) accept(name string, declaredSize int64) bool {
+	if l.budget.entries >= maxArchiveEntries {
+		l.stop(fmt.Sprintf("more than %d entries", maxArchiveEntries))
+		return false
+	}
+
+	if !isSafeArchivePath(name) {
+		l.reject(name, "unsafe path")
+		return false
+	}
+
+	if declaredSize > maxArchiveEntrySize {
+		l.reject(name, fmt.Sprintf("entry larger than %d MB", maxArchiveEntrySize>>20))
+		return false
+	}
+
+	if l.budget.totalSize+declaredSize > maxArchiveTotalSize {
+		l.stop(fmt.Sprintf("archive larger than %d MB when extracted", maxArchiveTotalSize>>20))
+		return false
+	}
+
+	return true
+}
+
+func (l *archiveLimiter) read(name string, source io.Reader) {
+	limited := io.LimitReader(source, maxArchiveEntrySize+1)
+
+	data, err := io.ReadAll(io.LimitReader(limited, maxInMemoryEntrySize+1))
+	if err != nil {
+		l.reject(name, err.Error())
+		return
+	}
+
+	entry := models.EmbeddedFile{Name: cleanArchivePath(name)}
+	size := int64(len(data))
+
+	if size > maxInMemoryEntrySize {
+		entry.Path, size, err = l.spill(data, limited)
+		if err != nil {
+			l.reject(name, err.Error())
+			return
+		}
+	} else {
+		entry.Data = data
+	}
+
+	if size > maxArchiveEntrySize {
+		removeSpilledEntry(entry)
+		l.reject(name, fmt.Sprintf("entry larger than %d MB", maxArchiveEntrySize>>20))
+		return
+	}
+
+	l.budget.totalSize += size
+	if l.budget.totalSize > maxArchiveTotalSize {
+		removeSpilledEntry(entry)
+		l.stop(fmt.Sprintf("archive larger than %d MB when extracted", maxArchiveTotalSize>>20))
+		return
+	}
+
+	l.add(entry)
+}
+
+func (l *archiveLimiter) spill(head []byte, rest io.Reader) (string, int64, error) {
+	if err := os.MkdirAll(l.budget.spillDir, 0755); err != nil {
+		return "", 0, fmt.Errorf("failed to spill entry to disk: %w", err)
+	}
+
+	file, err := os.CreateTemp(l.budget.spillDir, "entry-*")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to spill entry to disk: %w", err)
+	}
+	defer file.Close()
+
+	written, err := io.Copy(file, io.MultiReader(bytes.NewReader(head), rest))
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", 0, fmt.Errorf("failed to spill entry to disk: %w", err)
+	}
+
+	return file.Name(), written, nil
+}
+
+func (l *archiveLimiter) add(entry models.EmbeddedFile) {
+	l.budget.entries++
+	l.entries = append(l.entries, entry)
+}
+
+func (l *archiveLimiter) reject(name, reason string) {
+	l.add(models.EmbeddedFile{Name: cleanArchivePath(name), Error: "skipped: " + reason})
+}
+
+func (l *archiveLimiter) stop(reason string) {
+	l.stopped = true
+	if l.budget.stopped == "" {
+		l.budget.stopped = reason
+	}
+	l.entries = append(l.entries, models.EmbeddedFile{Name: "...", Error: "remaining entries skipped: " + reason})
+}
+
+func removeSpilledEntry(entry models.EmbeddedFile) {
+	if entry.Path != "" {
+		_ = os.Remove(entry.Path)
+	}
+}
+
+func cleanArchivePath(name string) string {
+	return path.Clean(strings.ReplaceAll(name, "\\", "/"))
+}
+
+func isSafeArchivePath(name string) bool {
+	cleaned := cleanArchivePath(name)
+
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") || strings.HasPrefix(cleaned, "/") {
+		return false
+	}
+
+	return !(len(cleaned) >= 2 && cleaned[1] == ':')
+}
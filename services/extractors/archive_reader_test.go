@@ -0,0 +1,198 @@
+package extractors
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+	"testing"
+)
+
+type archiveEntry struct {
+	name string
+	data []byte
+}
+
+func writeTestZip(t *testing.T, path string, method uint16, entries ...archiveEntry) {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, entry := range entries {
+		w, err := writer.CreateHeader(&zip.FileHeader{Name: entry.name, Method: method})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(entry.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestTar(t *testing.T, path string, entries ...archiveEntry) {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	for _, entry := range entries {
+		if err := writer.WriteHeader(&tar.Header{Name: entry.name, Mode: 0644, Size: int64(len(entry.data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write(entry.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func entryErrors(entries []models.EmbeddedFile) map[string]string {
+	errors := make(map[string]string)
+	for _, entry := range entries {
+		errors[entry.Name] = entry.Error
+	}
+	return errors
+}
+
+func TestReadEntriesRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	entries := []archiveEntry{
+		{"../evil.txt", []byte("x")},
+		{"docs/../../evil.txt", []byte("x")},
+		{"/etc/passwd", []byte("x")},
+		{`C:\Windows\evil.txt`, []byte("x")},
+		{`..\evil.txt`, []byte("x")},
+		{"docs/./nota.txt", []byte("nota fiscal")},
+	}
+
+	zipPath := filepath.Join(dir, "traversal.zip")
+	tarPath := filepath.Join(dir, "traversal.tar")
+	writeTestZip(t, zipPath, zip.Store, entries...)
+	writeTestTar(t, tarPath, entries...)
+
+	for _, archivePath := range []string{zipPath, tarPath} {
+		t.Run(filepath.Ext(archivePath), func(t *testing.T) {
+			read, err := (&ArchiveReader{}).ReadEntries(archivePath, DetectContentFormat(archivePath), NewArchiveBudget(filepath.Join(dir, "spill")))
+			if err != nil {
+				t.Fatalf("ReadEntries: %v", err)
+			}
+			if len(read) != len(entries) {
+				t.Fatalf("entries = %+v", read)
+			}
+
+			for _, entry := range read[:len(read)-1] {
+				if entry.Error != "skipped: unsafe path" || entry.Data != nil {
+					t.Errorf("entry %q = %+v, want it rejected", entry.Name, entry)
+				}
+			}
+
+			last := read[len(read)-1]
+			if last.Name != "docs/nota.txt" || last.Error != "" || string(last.Data) != "nota fiscal" {
+				t.Errorf("safe entry = %+v", last)
+			}
+		})
+	}
+}
+
+func TestReadEntriesRejectsZipBomb(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bomb.zip")
+	writeTestZip(t, path, zip.Deflate,
+		archiveEntry{"zeros.txt", make([]byte, 20<<20)},
+		archiveEntry{"nota.txt", []byte("nota fiscal")},
+	)
+
+	entries, err := (&ArchiveReader{}).ReadEntries(path, DetectContentFormat(path), NewArchiveBudget(filepath.Join(dir, "spill")))
+	if err != nil {
+		t.Fatalf("ReadEntries: %v", err)
+	}
+
+	errors := entryErrors(entries)
+	if !strings.Contains(errors["zeros.txt"], "compression ratio") {
+		t.Errorf("zeros.txt error = %q, want a compression ratio error", errors["zeros.txt"])
+	}
+	if _, ok := errors["nota.txt"]; !ok || errors["nota.txt"] != "" {
+		t.Errorf("entries = %v, want nota.txt read", errors)
+	}
+}
+
+func TestReadEntriesSharesBudgetAcrossArchives(t *testing.T) {
+	dir := t.TempDir()
+	budget := NewArchiveBudget(filepath.Join(dir, "spill"))
+
+	var entries []archiveEntry
+	for i := 0; i < 600; i++ {
+		entries = append(entries, archiveEntry{fmt.Sprintf("entry-%03d.txt", i), []byte("x")})
+	}
+	path := filepath.Join(dir, "entries.zip")
+	writeTestZip(t, path, zip.Store, entries...)
+
+	first, err := (&ArchiveReader{}).ReadEntries(path, DetectContentFormat(path), budget)
+	if err != nil || len(first) != 600 {
+		t.Fatalf("first read = %d entries, %v", len(first), err)
+	}
+
+	second, err := (&ArchiveReader{}).ReadEntries(path, DetectContentFormat(path), budget)
+	if err != nil {
+		t.Fatalf("second read: %v", err)
+	}
+	if len(second) != maxArchiveEntries-600+1 || !strings.Contains(second[len(second)-1].Error, "more than 1000 entries") {
+		t.Errorf("second read = %d entries ending in %+v", len(second), second[len(second)-1])
+	}
+
+	if _, err := (&ArchiveReader{}).ReadEntries(path, DetectContentFormat(path), budget); err == nil {
+		t.Error("third read succeeded after the budget was exhausted")
+	}
+
+	budget = NewArchiveBudget(filepath.Join(dir, "spill"))
+	budget.totalSize = maxArchiveTotalSize - 1
+	sized, err := (&ArchiveReader{}).ReadEntries(path, DetectContentFormat(path), budget)
+	if err != nil || len(sized) != 2 || !strings.Contains(sized[1].Error, "larger than 500 MB") {
+		t.Errorf("read with spent size budget = %+v, %v", sized, err)
+	}
+}
+
+func TestReadEntriesSpillsLargeEntries(t *testing.T) {
+	dir := t.TempDir()
+	spillDir := filepath.Join(dir, "spill")
+
+	large := make([]byte, maxInMemoryEntrySize+1024)
+	rand.New(rand.NewSource(1)).Read(large)
+
+	path := filepath.Join(dir, "large.zip")
+	writeTestZip(t, path, zip.Store,
+		archiveEntry{"large.bin", large},
+		archiveEntry{"small.txt", []byte("small")},
+	)
+
+	entries, err := (&ArchiveReader{}).ReadEntries(path, DetectContentFormat(path), NewArchiveBudget(spillDir))
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("ReadEntries = %+v, %v", entries, err)
+	}
+
+	spilled := entries[0]
+	if spilled.Data != nil || filepath.Dir(spilled.Path) != spillDir {
+		t.Fatalf("large entry = %q in %q, want it spilled to %s", spilled.Name, spilled.Path, spillDir)
+	}
+	data, err := os.ReadFile(spilled.Path)
+	if err != nil || !bytes.Equal(data, large) {
+		t.Errorf("spilled data differs (%d bytes, %v)", len(data), err)
+	}
+
+	if entries[1].Path != "" || string(entries[1].Data) != "small" {
+		t.Errorf("small entry = %+v, want it kept in memory", entries[1])
+	}
+}
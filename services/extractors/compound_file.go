@@ -0,0 +1,118 @@
+package extractors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/richardlehane/mscfb"
+)
+
+const maxCompoundStreamSize = 100 << 20
+
+var compoundFileSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+func isCompoundFile(filePath string) bool {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	header := make([]byte, len(compoundFileSignature))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false
+	}
+
+	return bytes.Equal(header, compoundFileSignature)
+}
+
+func readCompoundStreams(filePath string, wanted func(path string) bool) (streams map[string][]byte, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			streams, err = nil, fmt.Errorf("malformed compound file: %v", recovered)
+		}
+	}()
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open compound file: %w", err)
+	}
+	defer f.Close()
+
+	reader, err := openCompoundFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	streams = make(map[string][]byte)
+
+	for entry, err := reader.Next(); err == nil; entry, err = reader.Next() {
+		path := strings.Join(append(append([]string{}, entry.Path...), entry.Name), "/")
+		if entry.Size == 0 || !wanted(path) {
+			continue
+		}
+		if entry.Size < 0 || entry.Size > maxCompoundStreamSize {
+			return nil, fmt.Errorf("stream %s is too large (%d bytes)", path, entry.Size)
+		}
+
+		data := make([]byte, entry.Size)
+		if _, err := io.ReadFull(entry, data); err != nil {
+			return nil, fmt.Errorf("failed to read stream %s: %w", path, err)
+		}
+
+		streams[path] = data
+	}
+
+	return streams, nil
+}
+
+func openCompoundFile(f *os.File) (*mscfb.Reader, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compound file: %w", err)
+	}
+
+	header := make([]byte, 512)
+	if _, err := f.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("failed to read compound file header: %w", err)
+	}
+	if err := checkCompoundHeader(header, info.Size()); err != nil {
+		return nil, err
+	}
+
+	reader, err := mscfb.New(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compound file: %w", err)
+	}
+	return reader, nil
+}
+
+func checkCompoundHeader(header []byte, fileSize int64) error {
+	if !bytes.HasPrefix(header, compoundFileSignature) {
+		return fmt.Errorf("not a compound file")
+	}
+
+	sectorShift := binary.LittleEndian.Uint16(header[30:])
+	if sectorShift != 9 && sectorShift != 12 {
+		return fmt.Errorf("invalid compound file sector size")
+	}
+	sectors := fileSize >> sectorShift
+
+	counts := map[string]uint32{
+		"directory": binary.LittleEndian.Uint32(header[40:]),
+		"FAT":       binary.LittleEndian.Uint32(header[44:]),
+		"mini FAT":  binary.LittleEndian.Uint32(header[64:]),
+		"DIFAT":     binary.LittleEndian.Uint32(header[72:]),
+	}
+	for name, count := range counts {
+		if int64(count) > sectors {
+			return fmt.Errorf("compound file declares %d %s sectors but has only %d", count, name, sectors)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package extractors
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sniffLength          = 8192
+	maxPDFHeaderOffset   = 1024
+	minJunkTextRunLength = 3
+	maxMimetypeLength    = 256
+)
+
+var compatibleExtensions = map[string][]string{
+	".zip":  {".zip", ".docx", ".xlsx", ".pptx", ".odt", ".ods", ".odp"},
+	".ole":  {".doc", ".xls", ".msg"},
+	".jpg":  {".jpg", ".jpeg"},
+	".tif":  {".tif", ".tiff"},
+	".gz":   {".gz", ".tgz"},
+	".html": {".html", ".htm"},
+}
+
+var textContentFormats = map[string]bool{
+	".txt":  true,
+	".xml":  true,
+	".html": true,
+	".rtf":  true,
+}
+
+var binaryExtensions = map[string]bool{
+	".pdf": true, ".docx": true, ".xlsx": true, ".pptx": true, ".odt": true, ".ods": true, ".odp": true,
+	".doc": true, ".xls": true, ".msg": true, ".png": true, ".jpg": true, ".jpeg": true, ".gif": true,
+	".bmp": true, ".tif": true, ".tiff": true, ".zip": true, ".tar": true, ".gz": true, ".tgz": true,
+	".7z": true, ".rar": true,
+}
+
+type ContentFormat struct {
+	Extension string
+	Detected  string
+}
+
+func DetectContentFormat(filePath string) ContentFormat {
+	return ContentFormat{
+		Extension: fileExtension(filePath),
+		Detected:  sniffContent(filePath),
+	}
+}
+
+func fileExtension(filePath string) string {
+	if strings.HasSuffix(strings.ToLower(filePath), ".tar.gz") {
+		return ".tgz"
+	}
+	return strings.ToLower(filepath.Ext(filePath))
+}
+
+func (c ContentFormat) Mismatch() bool {
+	if c.Detected == "" || c.Extension == "" || c.matchesExtension() {
+		return false
+	}
+
+	if textContentFormats[c.Detected] {
+		return binaryExtensions[c.Extension]
+	}
+
+	return true
+}
+
+func (c ContentFormat) Format() string {
+	if c.Extension == "" || c.Mismatch() {
+		return c.Detected
+	}
+	return c.Extension
+}
+
+func (c ContentFormat) matchesExtension() bool {
+	if c.Detected == c.Extension {
+		return true
+	}
+
+	for _, extension := range compatibleExtensions[c.Detected] {
+		if extension == c.Extension {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sniffContent(filePath string) string {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffLength)
+	n, _ := file.Read(head)
+	head = head[:n]
+	if len(head) == 0 {
+		return ""
+	}
+
+	switch {
+	case hasPDFHeader(head):
+		return ".pdf"
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")):
+		return sniffZip(filePath)
+	case bytes.HasPrefix(head, compoundFileSignature):
+		return sniffCompoundFile(file)
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1a\n")):
+		return ".png"
+	case bytes.HasPrefix(head, []byte{0xFF, 0xD8, 0xFF}):
+		return ".jpg"
+	case bytes.HasPrefix(head, []byte("GIF87a")), bytes.HasPrefix(head, []byte("GIF89a")):
+		return ".gif"
+	case bytes.HasPrefix(head, []byte("II*\x00")), bytes.HasPrefix(head, []byte("MM\x00*")):
+		return ".tif"
+	case bytes.HasPrefix(head, []byte("BM")) && len(head) > 14 && bytes.Equal(head[6:10], []byte{0, 0, 0, 0}):
+		return ".bmp"
+	case bytes.HasPrefix(head, []byte{0x1F, 0x8B}):
+		return ".gz"
+	case len(head) > 262 && bytes.Equal(head[257:262], []byte("ustar")):
+		return ".tar"
+	case bytes.HasPrefix(head, []byte("7z\xBC\xAF\x27\x1C")):
+		return ".7z"
+	case bytes.HasPrefix(head, []byte("Rar!\x1A\x07")):
+		return ".rar"
+	}
+
+	return sniffText(head)
+}
+
+func hasPDFHeader(head []byte) bool {
+	offset := bytes.Index(head[:min(len(head), maxPDFHeaderOffset)], []byte("%PDF-"))
+	if offset < 0 {
+		return false
+	}
+
+	run := 0
+	for _, b := range head[:offset] {
+		switch {
+		case b == ' ' || b == '\t' || b == '\r' || b == '\n':
+		case b > ' ' && b < 0x7F:
+			run++
+			if run >= minJunkTextRunLength {
+				return false
+			}
+		default:
+			run = 0
+		}
+	}
+
+	return true
+}
+
+func sniffZip(filePath string) string {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return ".zip"
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		switch file.Name {
+		case "word/document.xml":
+			return ".docx"
+		case "xl/workbook.xml":
+			return ".xlsx"
+		case "ppt/presentation.xml":
+			return ".pptx"
+		case "mimetype":
+			if format := sniffOpenDocument(file); format != "" {
+				return format
+			}
+		}
+	}
+
+	return ".zip"
+}
+
+func sniffOpenDocument(file *zip.File) string {
+	rc, err := file.Open()
+	if err != nil {
+		return ""
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxMimetypeLength))
+	if err != nil {
+		return ""
+	}
+
+	switch strings.TrimSpace(string(data)) {
+	case "application/vnd.oasis.opendocument.text":
+		return ".odt"
+	case "application/vnd.oasis.opendocument.spreadsheet":
+		return ".ods"
+	case "application/vnd.oasis.opendocument.presentation":
+		return ".odp"
+	}
+	return ""
+}
+
+func sniffCompoundFile(file *os.File) (format string) {
+	defer func() {
+		if recover() != nil {
+			format = ".ole"
+		}
+	}()
+
+	reader, err := openCompoundFile(file)
+	if err != nil {
+		return ".ole"
+	}
+
+	for entry, err := reader.Next(); err == nil; entry, err = reader.Next() {
+		if len(entry.Path) > 0 {
+			continue
+		}
+
+		switch {
+		case entry.Name == "WordDocument":
+			return ".doc"
+		case entry.Name == "Workbook" || entry.Name == "Book":
+			return ".xls"
+		case strings.HasPrefix(entry.Name, "__substg1.0_") || entry.Name == "__properties_version1.0":
+			return ".msg"
+		}
+	}
+
+	return ".ole"
+}
+
+func sniffText(head []byte) string {
+	if bytes.HasPrefix(head, []byte{0xFF, 0xFE}) || bytes.HasPrefix(head, []byte{0xFE, 0xFF}) {
+		return ".txt"
+	}
+	if _, ok := guessUTF16(head); ok {
+		return ".txt"
+	}
+	if bytes.IndexByte(head, 0) >= 0 {
+		return ""
+	}
+
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xEF\xBB\xBF")), " \t\r\n")
+	lower := bytes.ToLower(trimmed)
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte(`{\rtf`)):
+		return ".rtf"
+	case bytes.HasPrefix(lower, []byte("<!doctype html")), bytes.HasPrefix(lower, []byte("<html")):
+		return ".html"
+	case bytes.HasPrefix(lower, []byte("<?xml")):
+		if bytes.Contains(lower, []byte("<html")) {
+			return ".html"
+		}
+		return ".xml"
+	}
+
+	return ".txt"
+}
@@ -0,0 +1,215 @@
+package extractors
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func zipWith(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range entries {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestDetectContentFormat(t *testing.T) {
+	tarHeader := make([]byte, 512)
+	copy(tarHeader, "nota.txt")
+	copy(tarHeader[257:], "ustar")
+
+	bmp := append([]byte("BM"), make([]byte, 20)...)
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"pdf", []byte("%PDF-1.4\n%âãÏÓ\n1 0 obj"), ".pdf"},
+		{"pdf after binary junk", []byte("\x00\x01\xFF\xFE\x00\x00%PDF-1.7\n"), ".pdf"},
+		{"pdf after whitespace", []byte("\r\n  %PDF-1.3\n"), ".pdf"},
+		{"log mentioning a pdf header", []byte("2024-03-15 INFO wrote %PDF-1.4 header\n"), ".txt"},
+		{"csv with a pdf marker", []byte("nome;conteudo\nrelatorio;%PDF-1.4\n"), ".txt"},
+		{"pdf header too far", append(bytes.Repeat([]byte{0}, maxPDFHeaderOffset), []byte("%PDF-1.4")...), ""},
+		{"zip", zipWith(t, map[string]string{"nota.txt": "x"}), ".zip"},
+		{"docx", zipWith(t, map[string]string{"word/document.xml": "<w:document/>"}), ".docx"},
+		{"xlsx", zipWith(t, map[string]string{"xl/workbook.xml": "<workbook/>"}), ".xlsx"},
+		{"pptx", zipWith(t, map[string]string{"ppt/presentation.xml": "<p:presentation/>"}), ".pptx"},
+		{"odt", zipWith(t, map[string]string{"mimetype": "application/vnd.oasis.opendocument.text"}), ".odt"},
+		{"ods", zipWith(t, map[string]string{"mimetype": "application/vnd.oasis.opendocument.spreadsheet"}), ".ods"},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0DIHDR"), ".png"},
+		{"jpeg", []byte{0xFF, 0xD8, 0xFF, 0xE0, 0, 0x10, 'J', 'F', 'I', 'F'}, ".jpg"},
+		{"gif", []byte("GIF89a\x01\x00\x01\x00"), ".gif"},
+		{"tiff little endian", []byte("II*\x00\x08\x00\x00\x00"), ".tif"},
+		{"tiff big endian", []byte("MM\x00*\x00\x00\x00\x08"), ".tif"},
+		{"bmp", bmp, ".bmp"},
+		{"text starting with BM", []byte("BMW e Mercedes: relatório de frota\n"), ".txt"},
+		{"gzip", []byte{0x1F, 0x8B, 0x08, 0x00}, ".gz"},
+		{"tar", tarHeader, ".tar"},
+		{"7z", []byte("7z\xBC\xAF\x27\x1C\x00\x04"), ".7z"},
+		{"rar", []byte("Rar!\x1A\x07\x01\x00"), ".rar"},
+		{"rtf", []byte(`{\rtf1\ansi Contrato}`), ".rtf"},
+		{"html", []byte("\xEF\xBB\xBF  <!DOCTYPE html><html><body>Oi</body></html>"), ".html"},
+		{"xhtml", []byte(`<?xml version="1.0"?><html xmlns="http://www.w3.org/1999/xhtml"></html>`), ".html"},
+		{"xml", []byte(`<?xml version="1.0"?><nfeProc/>`), ".xml"},
+		{"utf-16 text", []byte("\xFF\xFEO\x00l\x00\xE1\x00"), ".txt"},
+		{"plain text", []byte("Relatório mensal de vendas\n"), ".txt"},
+		{"binary", []byte{0x00, 0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0xFE}, ""},
+		{"empty", nil, ""},
+	}
+
+	dir := t.TempDir()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, "sample")
+			if err := os.WriteFile(path, test.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got := DetectContentFormat(path).Detected; got != test.want {
+				t.Errorf("Detected = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDetectContentFormatCompoundFiles(t *testing.T) {
+	tests := map[string]string{
+		"contrato.doc":  ".doc",
+		"notas.xls":     ".xls",
+		"relatorio.msg": ".msg",
+	}
+
+	for name, want := range tests {
+		if got := DetectContentFormat(filepath.Join("testdata", name)).Detected; got != want {
+			t.Errorf("%s detected as %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestContentFormatMismatch(t *testing.T) {
+	tests := []struct {
+		format       ContentFormat
+		wantMismatch bool
+		wantFormat   string
+	}{
+		{ContentFormat{".pdf", ".pdf"}, false, ".pdf"},
+		{ContentFormat{".docx", ".zip"}, false, ".docx"},
+		{ContentFormat{".xlsx", ".docx"}, true, ".docx"},
+		{ContentFormat{".doc", ".ole"}, false, ".doc"},
+		{ContentFormat{".msg", ".doc"}, true, ".doc"},
+		{ContentFormat{".jpeg", ".jpg"}, false, ".jpeg"},
+		{ContentFormat{".tiff", ".tif"}, false, ".tiff"},
+		{ContentFormat{".tgz", ".gz"}, false, ".tgz"},
+		{ContentFormat{".htm", ".html"}, false, ".htm"},
+		{ContentFormat{".pdf", ".txt"}, true, ".txt"},
+		{ContentFormat{".zip", ".html"}, true, ".html"},
+		{ContentFormat{".csv", ".txt"}, false, ".csv"},
+		{ContentFormat{".md", ".html"}, false, ".md"},
+		{ContentFormat{".eml", ".txt"}, false, ".eml"},
+		{ContentFormat{".txt", ".pdf"}, true, ".pdf"},
+		{ContentFormat{".csv", ".png"}, true, ".png"},
+		{ContentFormat{"", ".pdf"}, false, ".pdf"},
+		{ContentFormat{".xml", ""}, false, ".xml"},
+		{ContentFormat{"", ""}, false, ""},
+	}
+
+	for _, test := range tests {
+		if got := test.format.Mismatch(); got != test.wantMismatch {
+			t.Errorf("%+v.Mismatch() = %v, want %v", test.format, got, test.wantMismatch)
+		}
+		if got := test.format.Format(); got != test.wantFormat {
+			t.Errorf("%+v.Format() = %q, want %q", test.format, got, test.wantFormat)
+		}
+	}
+}
+
+func TestFileExtension(t *testing.T) {
+	tests := map[string]string{
+		"notas.PDF":           ".pdf",
+		"backup.tar.gz":       ".tgz",
+		"backup.TAR.GZ":       ".tgz",
+		"dir.v2/relatorio":    "",
+		"planilha.final.xlsx": ".xlsx",
+	}
+
+	for path, want := range tests {
+		if got := fileExtension(path); got != want {
+			t.Errorf("fileExtension(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestIsFormatSupportedChecksExtensionFirst(t *testing.T) {
+	factory := NewDocumentExtractorFactory()
+	dir := t.TempDir()
+
+	for _, name := range []string{"missing.pdf", "missing.DOCX", "missing.tar.gz", "missing.7z"} {
+		if !factory.IsFormatSupported(filepath.Join(dir, name)) {
+			t.Errorf("%s was not recognised by its extension", name)
+		}
+	}
+
+	textPath := filepath.Join(dir, "LEIAME")
+	binaryPath := filepath.Join(dir, "dados.bin")
+	if err := os.WriteFile(textPath, []byte("2024-03-15 INFO iniciado\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(binaryPath, []byte{0x00, 0x00, 0x01, 0x00, 0x02, 0x00, 0x00, 0xFE}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if !factory.IsFormatSupported(textPath) {
+		t.Error("text file without an extension was not sniffed")
+	}
+	if factory.IsFormatSupported(binaryPath) {
+		t.Error("binary file with an unknown extension was accepted")
+	}
+}
+
+func TestZipPartsAreReadWithinLimits(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "partes.zip")
+	writeTestZip(t, path, zip.Deflate,
+		archiveEntry{"mimetype", append([]byte("application/vnd.oasis.opendocument.text"), bytes.Repeat([]byte(" "), 1<<20)...)},
+		archiveEntry{"content.xml", make([]byte, maxZipPartSize+1)},
+		archiveEntry{"styles.xml", []byte("<office:document-styles/>")},
+	)
+
+	if got := DetectContentFormat(path).Detected; got != ".odt" {
+		t.Errorf("Detected = %q, want .odt", got)
+	}
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		data, err := readZipFile(file)
+		switch file.Name {
+		case "content.xml":
+			if err == nil {
+				t.Errorf("readZipFile read %d bytes of an oversized part", len(data))
+			}
+		case "styles.xml":
+			if err != nil || string(data) != "<office:document-styles/>" {
+				t.Errorf("readZipFile(styles.xml) = %q, %v", data, err)
+			}
+		}
+	}
+}
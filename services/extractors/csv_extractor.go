@@ -0,0 +1,127 @@
+package extractors
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+)
+
+const csvSniffLines = 20
+
+var csvDelimiters = []rune{',', ';', '\t', '|'}
+
+type CsvExtractor struct{}
+
+func (e *CsvExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return models.DocumentMetadata{}, err
+	}
+
+	content, encoding := decodeText(data)
+
+	delimiter := '\t'
+	if strings.ToLower(filepath.Ext(filePath)) != ".tsv" {
+		delimiter = sniffDelimiter(content)
+	}
+
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to parse delimited file: %w", err)
+	}
+
+	for i, row := range rows {
+		for j, cell := range row {
+			row[j] = strings.TrimSpace(cell)
+		}
+		rows[i] = trimTrailingEmpty(row)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	document := buildSpreadsheetDocument(filePath, []sheetData{{name: name, rows: rows, cellCount: countCells(rows)}}, strings.TrimPrefix(strings.ToLower(filepath.Ext(filePath)), "."))
+	document.Metadata["delimiter"] = delimiterName(delimiter)
+	document.Metadata["encoding"] = encoding
+
+	if len(document.Structure.Sheets) > 0 && len(document.Structure.Sheets[0].Headers) > 0 {
+		document.Metadata["columns"] = strings.Join(document.Structure.Sheets[0].Headers, ", ")
+	}
+
+	return document, nil
+}
+
+func sniffDelimiter(content string) rune {
+	lines := strings.SplitN(content, "\n", csvSniffLines+1)
+	if len(lines) > csvSniffLines {
+		lines = lines[:csvSniffLines]
+	}
+	sample := strings.Join(lines, "\n")
+
+	best, bestScore := ',', 0
+	for _, delimiter := range csvDelimiters {
+		reader := csv.NewReader(strings.NewReader(sample))
+		reader.Comma = delimiter
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+
+		var records [][]string
+		for {
+			record, err := reader.Read()
+			if err != nil {
+				break
+			}
+			records = append(records, record)
+		}
+		if len(records) == 0 {
+			continue
+		}
+
+		counts := make(map[int]int)
+		for _, record := range records {
+			if len(record) > 1 {
+				counts[len(record)]++
+			}
+		}
+
+		for fields, rows := range counts {
+			if score := rows * fields; rows*2 >= len(records) && score > bestScore {
+				best, bestScore = delimiter, score
+			}
+		}
+	}
+
+	return best
+}
+
+func trimTrailingEmpty(row []string) []string {
+	end := len(row)
+	for end > 0 && row[end-1] == "" {
+		end--
+	}
+	return row[:end]
+}
+
+func delimiterName(delimiter rune) string {
+	switch delimiter {
+	case '\t':
+		return "tab"
+	default:
+		return string(delimiter)
+	}
+}
+
+func (e *CsvExtractor) IsSupportedFormat(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return ext == ".csv" || ext == ".tsv"
+}
+
+func (e *CsvExtractor) GetSupportedFormats() []string {
+	return []string{".csv", ".tsv"}
+}
@@ -0,0 +1,86 @@
+package extractors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSniffDelimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    rune
+	}{
+		{"comma", "cliente,valor,data\nA,10,2024-01-02\nB,20,2024-01-03\n", ','},
+		{"semicolon with decimal commas", "cliente;valor\nA;1,50\nB;2,00\nC;3,25\n", ';'},
+		{"tab", "cliente\tvalor\nA\t1,50\nB\t2,00\n", '\t'},
+		{"pipe", "cliente|valor|data\nA|10|2024\nB|20|2024\n", '|'},
+		{"delimiters inside quotes", "\"Silva; Souza\",10,20\n\"Lima; Reis\",30,40\n", ','},
+		{"single column", "cliente\nA\nB\n", ','},
+		{"ragged rows keep the consistent delimiter", "titulo do relatorio\ncliente;valor;data\nA;1;2\nB;3;4\nC;5;6\n", ';'},
+		{"quoted field cut by the sample", "a;b\n" + strings.Repeat("x;y\n", 18) + "\"linha\naberta;z\n", ';'},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := sniffDelimiter(test.content); got != test.want {
+				t.Errorf("sniffDelimiter() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCsvExtractorExtractText(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name      string
+		filename  string
+		data      []byte
+		wantText  string
+		delimiter string
+		encoding  string
+		columns   string
+	}{
+		{
+			name:      "semicolon in windows-1252",
+			filename:  "vendas.csv",
+			data:      []byte("Descri\xe7\xe3o;Valor;\r\n Servi\xe7o \x96 m\xeas ;1,50;\r\n"),
+			wantText:  "\n[Sheet: vendas]\nDescrição | Valor\nServiço – mês | 1,50\n",
+			delimiter: ";",
+			encoding:  "windows-1252",
+			columns:   "Descrição, Valor",
+		},
+		{
+			name:      "tsv is always tab separated",
+			filename:  "itens.tsv",
+			data:      []byte("item\tobs\nA\tx,y,z\nB\tu,v,w\n"),
+			wantText:  "\n[Sheet: itens]\nitem | obs\nA | x,y,z\nB | u,v,w\n",
+			delimiter: "tab",
+			encoding:  "utf-8",
+			columns:   "item, obs",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.filename)
+			if err := os.WriteFile(path, test.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			document, err := (&CsvExtractor{}).ExtractText(path)
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+			if document.Text != test.wantText {
+				t.Errorf("text = %q, want %q", document.Text, test.wantText)
+			}
+			if document.Metadata["delimiter"] != test.delimiter || document.Metadata["encoding"] != test.encoding || document.Metadata["columns"] != test.columns {
+				t.Errorf("metadata = %v", document.Metadata)
+			}
+		})
+	}
+}
@@ -3,42 +3,117 @@ package extractors
 import (
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"relatorios/interfaces"
+	"relatorios/models"
+	"time"
 )
 
 type DocumentExtractorFactory struct {
-	extractors []interfaces.TextExtractor
+	extractors    []interfaces.TextExtractor
+	archiveReader *ArchiveReader
+	ocr           *OCREngine
 }
 
 func NewDocumentExtractorFactory() *DocumentExtractorFactory {
+	ocr := NewOCREngine(models.DefaultOCROptions())
+
 	return &DocumentExtractorFactory{
 		extractors: []interfaces.TextExtractor{
-			&PdfExtractor{},
+			&PdfExtractor{ocr: ocr},
 			&WordExtractor{},
 			&ExcelExtractor{},
+			&OdtExtractor{},
+			&OdsExtractor{},
+			&OdpExtractor{},
+			&PptxExtractor{},
+			&EmlExtractor{},
+			&MsgExtractor{},
+			&HtmlExtractor{},
+			&MarkdownExtractor{},
+			&RtfExtractor{},
+			&CsvExtractor{},
+			&NfeExtractor{},
 			&TextFileExtractor{},
-			&ImageExtractor{},
+			&ImageExtractor{ocr: ocr},
 		},
+		archiveReader: &ArchiveReader{},
+		ocr:           ocr,
 	}
 }
 
-func (f *DocumentExtractorFactory) GetExtractorForFile(filePath string) (interfaces.TextExtractor, error) {
-	for _, extractor := range f.extractors {
-		if extractor.IsSupportedFormat(filePath) {
-			return extractor, nil
-		}
+func (f *DocumentExtractorFactory) GetExtractorForFile(filePath string, format ContentFormat) (interfaces.TextExtractor, error) {
+	if extractor := f.extractorForFormat(format.Format()); extractor != nil {
+		return extractor, nil
+	}
+
+	if format.Format() == "" {
+		return nil, fmt.Errorf("unsupported file format: %s", filepath.Base(filePath))
+	}
+	return nil, fmt.Errorf("unsupported file format: %s", format.Format())
+}
+
+func (f *DocumentExtractorFactory) ExtractDocument(filePath string, format ContentFormat, ocrOptions models.OCROptions) (models.DocumentMetadata, error) {
+	extractor, err := f.GetExtractorForFile(filePath, format)
+	if err != nil {
+		return models.DocumentMetadata{}, err
+	}
+
+	digest := hashFile(filePath)
+
+	started := time.Now()
+	var document models.DocumentMetadata
+	if ocrExtractor, ok := extractor.(interfaces.OCRTextExtractor); ok {
+		document, err = ocrExtractor.ExtractTextWithOCR(filePath, ocrOptions)
+	} else {
+		document, err = extractor.ExtractText(filePath)
+	}
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to extract text: %w", err)
+	}
+
+	info := documentInfo(&document)
+	info.Extractor = reflect.TypeOf(extractor).Elem().Name()
+	info.ExtractionMs = time.Since(started).Milliseconds()
+
+	if err := describeFile(filePath, format, <-digest, info); err != nil {
+		return models.DocumentMetadata{}, err
 	}
 
-	return nil, fmt.Errorf("unsupported file format: %s", filepath.Ext(filePath))
+	return document, nil
 }
 
 func (f *DocumentExtractorFactory) IsFormatSupported(filePath string) bool {
+	extension := ContentFormat{Extension: fileExtension(filePath)}
+	if f.SupportsFormat(extension) {
+		return true
+	}
+	return f.SupportsFormat(DetectContentFormat(filePath))
+}
+
+func (f *DocumentExtractorFactory) SupportsFormat(format ContentFormat) bool {
+	return f.HasExtractor(format) || f.IsArchiveFormat(format)
+}
+
+func (f *DocumentExtractorFactory) HasExtractor(format ContentFormat) bool {
+	return f.extractorForFormat(format.Format()) != nil
+}
+
+func (f *DocumentExtractorFactory) DetectFormat(filePath string) ContentFormat {
+	return DetectContentFormat(filePath)
+}
+
+func (f *DocumentExtractorFactory) extractorForFormat(format string) interfaces.TextExtractor {
+	if format == "" {
+		return nil
+	}
+
 	for _, extractor := range f.extractors {
-		if extractor.IsSupportedFormat(filePath) {
-			return true
+		if extractor.IsSupportedFormat(format) {
+			return extractor
 		}
 	}
-	return false
+	return nil
 }
 
 func (f *DocumentExtractorFactory) GetSupportedFormats() []string {
@@ -46,5 +121,13 @@ func (f *DocumentExtractorFactory) GetSupportedFormats() []string {
 	for _, extractor := range f.extractors {
 		formats = append(formats, extractor.GetSupportedFormats()...)
 	}
-	return formats
+	return append(formats, f.archiveReader.GetSupportedFormats()...)
+}
+
+func (f *DocumentExtractorFactory) IsArchiveFormat(format ContentFormat) bool {
+	return format.Format() != "" && f.archiveReader.IsSupportedFormat(format.Format())
+}
+
+func (f *DocumentExtractorFactory) ReadArchive(filePath string, format ContentFormat, budget *ArchiveBudget) ([]models.EmbeddedFile, error) {
+	return f.archiveReader.ReadEntries(filePath, format, budget)
 }
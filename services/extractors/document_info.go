@@ -0,0 +1,243 @@
+package extractors
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var formatMimeTypes = map[string]string{
+	".pdf":      "application/pdf",
+	".docx":     "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".doc":      "application/msword",
+	".xlsx":     "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".xls":      "application/vnd.ms-excel",
+	".pptx":     "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".odt":      "application/vnd.oasis.opendocument.text",
+	".ods":      "application/vnd.oasis.opendocument.spreadsheet",
+	".odp":      "application/vnd.oasis.opendocument.presentation",
+	".eml":      "message/rfc822",
+	".msg":      "application/vnd.ms-outlook",
+	".html":     "text/html",
+	".htm":      "text/html",
+	".md":       "text/markdown",
+	".markdown": "text/markdown",
+	".rtf":      "application/rtf",
+	".csv":      "text/csv",
+	".tsv":      "text/tab-separated-values",
+	".xml":      "application/xml",
+	".txt":      "text/plain",
+	".png":      "image/png",
+	".jpg":      "image/jpeg",
+	".jpeg":     "image/jpeg",
+	".gif":      "image/gif",
+	".bmp":      "image/bmp",
+	".tif":      "image/tiff",
+	".tiff":     "image/tiff",
+	".zip":      "application/zip",
+	".tar":      "application/x-tar",
+	".tgz":      "application/gzip",
+	".gz":       "application/gzip",
+}
+
+var documentDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+type fileDigest struct {
+	size   int64
+	sha256 string
+	err    error
+}
+
+func hashFile(filePath string) <-chan fileDigest {
+	result := make(chan fileDigest, 1)
+
+	go func() {
+		file, err := os.Open(filePath)
+		if err != nil {
+			result <- fileDigest{err: fmt.Errorf("failed to open file: %w", err)}
+			return
+		}
+		defer file.Close()
+
+		hash := sha256.New()
+		size, err := io.Copy(hash, file)
+		if err != nil {
+			result <- fileDigest{err: fmt.Errorf("failed to hash file: %w", err)}
+			return
+		}
+
+		result <- fileDigest{size: size, sha256: hex.EncodeToString(hash.Sum(nil))}
+	}()
+
+	return result
+}
+
+func describeFile(filePath string, format ContentFormat, digest fileDigest, info *models.DocumentInfo) error {
+	if digest.err != nil {
+		return digest.err
+	}
+
+	if absolutePath, err := filepath.Abs(filePath); err == nil {
+		filePath = absolutePath
+	}
+
+	info.Path = filePath
+	info.Size = digest.size
+	info.SHA256 = digest.sha256
+	info.MimeType = formatMimeTypes[format.Format()]
+
+	return nil
+}
+
+func documentInfo(document *models.DocumentMetadata) *models.DocumentInfo {
+	if document.Info == nil {
+		document.Info = &models.DocumentInfo{}
+	}
+	return document.Info
+}
+
+func readPackageProperties(filePath string, info *models.DocumentInfo) {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		switch file.Name {
+		case "docProps/core.xml":
+			if root := readZipXML(file); root != nil {
+				setText(&info.Title, root, "title")
+				setText(&info.Author, root, "creator")
+				setDate(&info.Created, root, "created")
+				setDate(&info.Modified, root, "modified")
+			}
+		case "docProps/app.xml":
+			if root := readZipXML(file); root != nil {
+				setCount(&info.PageCount, root, "Pages")
+				setCount(&info.SlideCount, root, "Slides")
+			}
+		case "meta.xml":
+			if root := readZipXML(file); root != nil {
+				setText(&info.Title, root, "title")
+				setText(&info.Author, root, "initial-creator")
+				setText(&info.Author, root, "creator")
+				setDate(&info.Created, root, "creation-date")
+				setDate(&info.Modified, root, "date")
+				for _, statistic := range root.find("document-statistic") {
+					if pages, err := strconv.Atoi(statistic.attr("page-count")); err == nil && info.PageCount == 0 {
+						info.PageCount = pages
+					}
+				}
+			}
+		}
+	}
+}
+
+func readZipXML(file *zip.File) *xmlNode {
+	data, err := readZipFile(file)
+	if err != nil {
+		return nil
+	}
+
+	root, err := parseXMLTree(data)
+	if err != nil {
+		return nil
+	}
+	return root
+}
+
+func nodeText(root *xmlNode, name string) string {
+	nodes := root.find(name)
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	var text strings.Builder
+	for _, child := range nodes[0].children {
+		text.WriteString(child.text)
+	}
+	return strings.TrimSpace(text.String())
+}
+
+func setText(target *string, root *xmlNode, name string) {
+	if *target == "" {
+		*target = nodeText(root, name)
+	}
+}
+
+func setDate(target **time.Time, root *xmlNode, name string) {
+	if *target == nil {
+		*target = parseDocumentDate(nodeText(root, name))
+	}
+}
+
+func setCount(target *int, root *xmlNode, name string) {
+	if count, err := strconv.Atoi(nodeText(root, name)); err == nil && *target == 0 {
+		*target = count
+	}
+}
+
+func parseDocumentDate(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	for _, layout := range documentDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed
+		}
+	}
+	return nil
+}
+
+func parsePDFDate(value string) *time.Time {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "D:")
+	digits := value
+	zone := ""
+	if index := strings.IndexAny(value, "Z+-"); index >= 0 {
+		digits, zone = value[:index], value[index:]
+	}
+
+	if len(digits) < 4 {
+		return nil
+	}
+	if len(digits) < 14 {
+		digits += "0101000000"[len(digits)-4:]
+	}
+
+	parsed, err := time.Parse("20060102150405", digits[:14])
+	if err != nil {
+		return nil
+	}
+
+	zone = strings.ReplaceAll(zone, "'", "")
+	if len(zone) >= 3 && (zone[0] == '+' || zone[0] == '-') {
+		hours, _ := strconv.Atoi(zone[1:3])
+		minutes := 0
+		if len(zone) >= 5 {
+			minutes, _ = strconv.Atoi(zone[3:5])
+		}
+		offset := hours*3600 + minutes*60
+		if zone[0] == '-' {
+			offset = -offset
+		}
+		parsed = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), 0, time.FixedZone("", offset))
+	}
+
+	return &parsed
+}
@@ -0,0 +1,64 @@
+package extractors
+
+import (
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"testing"
+	"time"
+)
+
+func TestParsePDFDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"D:20240315103000-03'00'", "2024-03-15T10:30:00-03:00"},
+		{"D:20240315103000+05'30'", "2024-03-15T10:30:00+05:30"},
+		{"D:20240315103000-03", "2024-03-15T10:30:00-03:00"},
+		{"D:20240315103000Z", "2024-03-15T10:30:00Z"},
+		{"D:20240315103000Z00'00'", "2024-03-15T10:30:00Z"},
+		{"D:20240315103000", "2024-03-15T10:30:00Z"},
+		{" D:202403151030 ", "2024-03-15T10:30:00Z"},
+		{"D:202403", "2024-03-01T00:00:00Z"},
+		{"D:2024", "2024-01-01T00:00:00Z"},
+		{"20240315", "2024-03-15T00:00:00Z"},
+		{"D:20241315103000", ""},
+		{"D:20", ""},
+		{"D:", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		got := parsePDFDate(test.value)
+		switch {
+		case test.want == "" && got != nil:
+			t.Errorf("parsePDFDate(%q) = %v, want nil", test.value, got)
+		case test.want != "" && got == nil:
+			t.Errorf("parsePDFDate(%q) = nil, want %s", test.value, test.want)
+		case got != nil && got.Format(time.RFC3339) != test.want:
+			t.Errorf("parsePDFDate(%q) = %s, want %s", test.value, got.Format(time.RFC3339), test.want)
+		}
+	}
+}
+
+func TestDescribeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nota.txt")
+	if err := os.WriteFile(path, []byte("abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info := &models.DocumentInfo{}
+	if err := describeFile(path, ContentFormat{Extension: ".txt", Detected: ".txt"}, <-hashFile(path), info); err != nil {
+		t.Fatalf("describeFile: %v", err)
+	}
+
+	if info.Size != 3 || info.SHA256 != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" || info.MimeType != "text/plain" || info.Path != path {
+		t.Errorf("info = %+v", info)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+	if err := describeFile(missing, ContentFormat{}, <-hashFile(missing), &models.DocumentInfo{}); err == nil {
+		t.Error("describeFile succeeded for a missing file")
+	}
+}
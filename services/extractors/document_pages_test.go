@@ -0,0 +1,121 @@
+package extractors
+
+import (
+	"archive/zip"
+	"path/filepath"
+	"reflect"
+	"relatorios/models"
+	"testing"
+)
+
+func pageLines(pages []models.DocumentPage) [][]string {
+	lines := make([][]string, 0, len(pages))
+	for _, page := range pages {
+		lines = append(lines, page.Lines)
+	}
+	return lines
+}
+
+func TestWordExtractorSplitsPages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relatorio.docx")
+	writeTestZip(t, path, zip.Deflate, archiveEntry{"word/document.xml", []byte(`<?xml version="1.0"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Capa</w:t></w:r></w:p>
+<w:p/>
+<w:p><w:r><w:t>Sumário</w:t><w:br w:type="page"/><w:t>Introdução</w:t></w:r></w:p>
+<w:p><w:r><w:t>Texto</w:t></w:r></w:p>
+<w:p><w:pPr><w:pageBreakBefore/></w:pPr><w:r><w:t>Conclusão</w:t></w:r></w:p>
+<w:p><w:pPr><w:pageBreakBefore w:val="false"/></w:pPr><w:r><w:t>Assinaturas</w:t></w:r></w:p>
+</w:body></w:document>`)})
+
+	document, err := (&WordExtractor{}).ExtractText(path)
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+
+	want := [][]string{{"Capa", "", "Sumário"}, {"Introdução", "Texto"}, {"Conclusão", "Assinaturas"}}
+	if got := pageLines(document.Pages); !reflect.DeepEqual(got, want) {
+		t.Errorf("pages = %q, want %q", got, want)
+	}
+	if wantText := "Capa\n\nSumário\nIntrodução\nTexto\nConclusão\nAssinaturas\n"; document.Text != wantText {
+		t.Errorf("text = %q, want %q", document.Text, wantText)
+	}
+}
+
+func TestWordExtractorWithoutPageBreaks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "carta.docx")
+	writeTestZip(t, path, zip.Deflate, archiveEntry{"word/document.xml", []byte(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Prezados,</w:t></w:r></w:p>
+</w:body></w:document>`)})
+
+	document, err := (&WordExtractor{}).ExtractText(path)
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+	if document.Pages != nil {
+		t.Errorf("pages = %+v, want none", document.Pages)
+	}
+}
+
+func TestOdtExtractorSplitsPages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relatorio.odt")
+	writeTestZip(t, path, zip.Deflate,
+		archiveEntry{"content.xml", []byte(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0">
+<office:automatic-styles>
+<style:style style:name="P1" style:family="paragraph"><style:paragraph-properties fo:break-before="page"/></style:style>
+</office:automatic-styles>
+<office:body><office:text>
+<text:p>Capa</text:p>
+<text:p/>
+<text:p>Resumo</text:p>
+<text:soft-page-break/>
+<text:p>Introdução</text:p>
+<text:p text:style-name="P1">Conclusão</text:p>
+<text:p text:style-name="Quebra">Anexo</text:p>
+</office:text></office:body></office:document-content>`)},
+		archiveEntry{"styles.xml", []byte(`<office:document-styles xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0">
+<office:styles>
+<style:style style:name="Quebra" style:family="paragraph"><style:paragraph-properties fo:break-before="page"/></style:style>
+</office:styles></office:document-styles>`)})
+
+	document, err := (&OdtExtractor{}).ExtractText(path)
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+
+	want := [][]string{{"Capa", "", "Resumo"}, {"Introdução"}, {"Conclusão"}, {"Anexo"}}
+	if got := pageLines(document.Pages); !reflect.DeepEqual(got, want) {
+		t.Errorf("pages = %q, want %q", got, want)
+	}
+	if wantText := "Capa\n\nResumo\nIntrodução\nConclusão\nAnexo\n"; document.Text != wantText {
+		t.Errorf("text = %q, want %q", document.Text, wantText)
+	}
+}
+
+func TestPresentationPagesFollowSlides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apresentacao.pptx")
+	slide := func(text string) []byte {
+		return []byte(`<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><p:cSld><p:spTree><p:sp><p:nvSpPr><p:nvPr><p:ph type="body"/></p:nvPr></p:nvSpPr><p:txBody>` + text + `</p:txBody></p:sp></p:spTree></p:cSld></p:sld>`)
+	}
+	writeTestZip(t, path, zip.Deflate,
+		archiveEntry{"ppt/presentation.xml", []byte(`<p:presentation xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><p:sldIdLst><p:sldId id="256" r:id="rId1"/><p:sldId id="257" r:id="rId2"/></p:sldIdLst></p:presentation>`)},
+		archiveEntry{"ppt/_rels/presentation.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Target="slides/slide1.xml"/><Relationship Id="rId2" Target="slides/slide2.xml"/></Relationships>`)},
+		archiveEntry{"ppt/slides/slide1.xml", slide(`<a:p><a:r><a:t>Resultados</a:t></a:r></a:p><a:p><a:r><a:t>2024</a:t></a:r></a:p>`)},
+		archiveEntry{"ppt/slides/_rels/slide1.xml.rels", []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/></Relationships>`)},
+		archiveEntry{"ppt/notesSlides/notesSlide1.xml", slide(`<a:p><a:r><a:t>Falar do trimestre</a:t></a:r></a:p>`)},
+		archiveEntry{"ppt/slides/slide2.xml", slide(`<a:p><a:r><a:t>Obrigado</a:t></a:r></a:p>`)},
+	)
+
+	document, err := (&PptxExtractor{}).ExtractText(path)
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+
+	want := []models.DocumentPage{
+		{Number: 1, Lines: []string{"Resultados", "2024", "", "Falar do trimestre"}},
+		{Number: 2, Lines: []string{"Obrigado"}},
+	}
+	if !reflect.DeepEqual(document.Pages, want) {
+		t.Errorf("pages = %+v, want %+v", document.Pages, want)
+	}
+}
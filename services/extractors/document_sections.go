@@ -0,0 +1,100 @@
+package extractors
+
+import (
+	"fmt"
+	"relatorios/models"
+	"strings"
+)
+
+const (
+	sectionBody      = "body"
+	sectionHeader    = "header"
+	sectionFooter    = "footer"
+	sectionFootnotes = "footnotes"
+	sectionEndnotes  = "endnotes"
+	sectionComments  = "comments"
+	sectionTextBox   = "textbox"
+	sectionNotes     = "notes"
+
+	pageBreak = "\f"
+)
+
+var documentSectionOrder = []string{sectionBody, sectionHeader, sectionFooter, sectionFootnotes, sectionEndnotes, sectionComments, sectionTextBox}
+
+type sectionTexts struct {
+	texts map[string][]string
+	seen  map[string]bool
+}
+
+func newSectionTexts() *sectionTexts {
+	return &sectionTexts{
+		texts: make(map[string][]string),
+		seen:  make(map[string]bool),
+	}
+}
+
+func (s *sectionTexts) add(section, text string) {
+	text = strings.TrimSpace(text)
+	key := section + "\x00" + text
+	if text == "" || s.seen[key] {
+		return
+	}
+
+	s.seen[key] = true
+	s.texts[section] = append(s.texts[section], text)
+}
+
+func (s *sectionTexts) result() map[string]string {
+	result := make(map[string]string, len(s.texts))
+	for section, texts := range s.texts {
+		result[section] = strings.Join(texts, "\n")
+	}
+	return result
+}
+
+func formatDocumentSections(sections map[string]string) string {
+	var builder strings.Builder
+
+	for _, section := range documentSectionOrder {
+		text, ok := sections[section]
+		if !ok {
+			continue
+		}
+
+		if section != sectionBody {
+			builder.WriteString(fmt.Sprintf("\n[%s]\n", sectionTitle(section)))
+		}
+		builder.WriteString(text)
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+func splitBodyPages(sections map[string]string) []models.DocumentPage {
+	body := sections[sectionBody]
+	for section, text := range sections {
+		sections[section] = strings.ReplaceAll(text, pageBreak, "")
+	}
+
+	var pages []models.DocumentPage
+	for _, text := range strings.Split(body, pageBreak) {
+		if strings.TrimSpace(text) != "" {
+			pages = append(pages, models.NewDocumentPage(len(pages)+1, text))
+		}
+	}
+
+	if len(pages) < 2 {
+		return nil
+	}
+	return pages
+}
+
+func sectionTitle(section string) string {
+	switch section {
+	case sectionTextBox:
+		return "Text box"
+	default:
+		return strings.ToUpper(section[:1]) + section[1:]
+	}
+}
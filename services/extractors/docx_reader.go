@@ -0,0 +1,227 @@
+package extractors
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	docxHeaderPart   = regexp.MustCompile(`^word/header\d*\.xml$`)
+	docxFooterPart   = regexp.MustCompile(`^word/footer\d*\.xml$`)
+	docxSectionParts = map[string]string{
+		"word/footnotes.xml": sectionFootnotes,
+		"word/endnotes.xml":  sectionEndnotes,
+		"word/comments.xml":  sectionComments,
+	}
+)
+
+type docxContainer struct {
+	text      strings.Builder
+	paragraph strings.Builder
+	row       []string
+}
+
+func (c *docxContainer) flushParagraph() {
+	c.text.WriteString(c.paragraph.String())
+	c.text.WriteString("\n")
+	c.paragraph.Reset()
+}
+
+type docxPartParser struct {
+	containers []*docxContainer
+	textBoxes  []string
+	inText     bool
+}
+
+func readDocxSections(filePath string) (map[string]string, error) {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	parts := make(map[string]*zip.File)
+	names := make([]string, 0, len(reader.File))
+	for _, file := range reader.File {
+		parts[file.Name] = file
+		names = append(names, file.Name)
+	}
+	sort.Strings(names)
+
+	if parts["word/document.xml"] == nil {
+		return nil, fmt.Errorf("word/document.xml not found")
+	}
+
+	sections := newSectionTexts()
+
+	readPart := func(name, section string) error {
+		text, textBoxes, err := parseDocxPart(parts[name])
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path.Base(name), err)
+		}
+
+		sections.add(section, text)
+		for _, textBox := range textBoxes {
+			sections.add(sectionTextBox, textBox)
+		}
+		return nil
+	}
+
+	if err := readPart("word/document.xml", sectionBody); err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		section := docxSectionParts[name]
+		switch {
+		case docxHeaderPart.MatchString(name):
+			section = sectionHeader
+		case docxFooterPart.MatchString(name):
+			section = sectionFooter
+		}
+
+		if section == "" {
+			continue
+		}
+
+		if err := readPart(name, section); err != nil {
+			return nil, err
+		}
+	}
+
+	return sections.result(), nil
+}
+
+func parseDocxPart(file *zip.File) (string, []string, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return "", nil, err
+	}
+	defer rc.Close()
+
+	parser := &docxPartParser{containers: []*docxContainer{{}}}
+	if err := parser.parse(xml.NewDecoder(rc)); err != nil {
+		return "", nil, err
+	}
+
+	root := parser.containers[0]
+	if root.paragraph.Len() > 0 {
+		root.flushParagraph()
+	}
+
+	return root.text.String(), parser.textBoxes, nil
+}
+
+func (p *docxPartParser) top() *docxContainer {
+	return p.containers[len(p.containers)-1]
+}
+
+func (p *docxPartParser) push() {
+	p.containers = append(p.containers, &docxContainer{})
+}
+
+func (p *docxPartParser) pop() *docxContainer {
+	container := p.top()
+	if container.paragraph.Len() > 0 {
+		container.flushParagraph()
+	}
+	p.containers = p.containers[:len(p.containers)-1]
+	return container
+}
+
+func (p *docxPartParser) parse(decoder *xml.Decoder) error {
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if err := p.start(decoder, element); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			p.end(element)
+		case xml.CharData:
+			if p.inText {
+				p.top().paragraph.Write(element)
+			}
+		}
+	}
+}
+
+func (p *docxPartParser) start(decoder *xml.Decoder, element xml.StartElement) error {
+	switch element.Name.Local {
+	case "Fallback", "delText", "instrText", "tabs":
+		return decoder.Skip()
+	case "footnote", "endnote":
+		for _, attr := range element.Attr {
+			if attr.Name.Local == "type" && strings.Contains(attr.Value, "eparator") {
+				return decoder.Skip()
+			}
+		}
+	case "t":
+		p.inText = true
+	case "tab":
+		p.top().paragraph.WriteString("\t")
+	case "br":
+		p.top().paragraph.WriteString("\n")
+		if elementAttr(element, "type") == "page" {
+			p.top().paragraph.WriteString(pageBreak)
+		}
+	case "cr":
+		p.top().paragraph.WriteString("\n")
+	case "lastRenderedPageBreak":
+		p.top().paragraph.WriteString(pageBreak)
+	case "pageBreakBefore":
+		if value := elementAttr(element, "val"); value != "0" && value != "false" && value != "off" {
+			p.top().paragraph.WriteString(pageBreak)
+		}
+	case "tc", "txbxContent":
+		p.push()
+	}
+
+	return nil
+}
+
+func elementAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func (p *docxPartParser) end(element xml.EndElement) {
+	switch element.Name.Local {
+	case "t":
+		p.inText = false
+	case "p":
+		p.top().flushParagraph()
+	case "tc":
+		cell := p.pop()
+		p.top().row = append(p.top().row, strings.Join(strings.Fields(cell.text.String()), " "))
+	case "tr":
+		container := p.top()
+		if container.paragraph.Len() > 0 {
+			container.flushParagraph()
+		}
+		container.text.WriteString(strings.Join(container.row, " | "))
+		container.text.WriteString("\n")
+		container.row = nil
+	case "txbxContent":
+		textBox := p.pop()
+		p.textBoxes = append(p.textBoxes, textBox.text.String())
+	}
+}
@@ -0,0 +1,68 @@
+package extractors
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestEmlExtractorDecodesMIMEParts(t *testing.T) {
+	document, err := (&EmlExtractor{}).ExtractText(filepath.Join("testdata", "fatura.eml"))
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+
+	wantText := "From: João Silva <joao@exemplo.com.br>\n" +
+		"To: financeiro@exemplo.com.br\n" +
+		"Subject: Fatura de março\n" +
+		"Date: 2024-03-15T10:30:00-03:00\n" +
+		"\nOlá equipe,\n" +
+		"Segue a fatura de março com vencimento em 15/03/2024.\n" +
+		"Valor total: R$ 1.500,00 — pagamento à vista.\n" +
+		"\n[Attachments]\n" +
+		"fatura março.txt\n" +
+		"logo.png\n"
+	if document.Text != wantText {
+		t.Errorf("text = %q, want %q", document.Text, wantText)
+	}
+
+	if document.Metadata["subject"] != "Fatura de março" || document.Metadata["attachmentCount"] != "2" {
+		t.Errorf("metadata = %v", document.Metadata)
+	}
+
+	if len(document.Attachments) != 2 {
+		t.Fatalf("attachments = %+v, want 2", document.Attachments)
+	}
+	if got := string(document.Attachments[0].Data); got != "Fatura 123\nValor: R$ 1.500,00\n" {
+		t.Errorf("base64 attachment = %q", got)
+	}
+	if got := document.Attachments[1].Data; len(got) != 24 || string(got[1:4]) != "PNG" {
+		t.Errorf("inline image = %q", got)
+	}
+}
+
+func TestMsgExtractorReadsProperties(t *testing.T) {
+	document, err := (&MsgExtractor{}).ExtractText(filepath.Join("testdata", "relatorio.msg"))
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+
+	wantText := "From: Maria Souza <maria@exemplo.com.br>\n" +
+		"To: Diretoria\n" +
+		"Subject: Relatório mensal\n" +
+		"Date: 2024-03-15T13:30:00Z\n" +
+		"\nPrezados,\n" +
+		"Em anexo o relatório de março.\n" +
+		"\n[Attachments]\n" +
+		"relatório.txt\n"
+	if document.Text != wantText {
+		t.Errorf("text = %q, want %q", document.Text, wantText)
+	}
+
+	if document.Metadata["skippedAttachments"] != "1 (embedded Outlook items)" {
+		t.Errorf("metadata = %v", document.Metadata)
+	}
+
+	if len(document.Attachments) != 1 || string(document.Attachments[0].Data) != "Receita: R$ 10.000,00\n" {
+		t.Errorf("attachments = %+v", document.Attachments)
+	}
+}
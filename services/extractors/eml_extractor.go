@@ -0,0 +1,250 @@
+package extractors
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxMIMEDepth = 10
+
+type emailHeaders struct {
+	From    string
+	To      string
+	Cc      string
+	Subject string
+	Date    string
+}
+
+type emailContent struct {
+	plain       []string
+	html        []string
+	attachments []models.EmbeddedFile
+}
+
+type EmlExtractor struct{}
+
+func (e *EmlExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to open email: %w", err)
+	}
+	defer file.Close()
+
+	message, err := mail.ReadMessage(file)
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to parse email: %w", err)
+	}
+
+	decoder := &mime.WordDecoder{CharsetReader: charsetReader}
+	decodeHeader := func(name string) string {
+		value := message.Header.Get(name)
+		if decoded, err := decoder.DecodeHeader(value); err == nil {
+			return decoded
+		}
+		return value
+	}
+
+	headers := emailHeaders{
+		From:    decodeHeader("From"),
+		To:      decodeHeader("To"),
+		Cc:      decodeHeader("Cc"),
+		Subject: decodeHeader("Subject"),
+		Date:    message.Header.Get("Date"),
+	}
+	if date, err := message.Header.Date(); err == nil {
+		headers.Date = date.Format(time.RFC3339)
+	}
+
+	content := &emailContent{}
+	if err := e.readEntity(textproto.MIMEHeader(message.Header), message.Body, content, decoder, 0); err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to read email body: %w", err)
+	}
+
+	return buildEmailDocument(filePath, headers, content.bodyText(), content.attachments, "eml"), nil
+}
+
+func (e *EmlExtractor) readEntity(header textproto.MIMEHeader, body io.Reader, content *emailContent, decoder *mime.WordDecoder, depth int) error {
+	if depth > maxMIMEDepth {
+		return fmt.Errorf("MIME structure nested too deeply")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return e.readMultipart(mediaType, params["boundary"], body, content, decoder, depth)
+	}
+
+	data, err := io.ReadAll(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if decoded, err := decoder.DecodeHeader(filename); err == nil {
+		filename = decoded
+	}
+
+	switch {
+	case mediaType == "message/rfc822":
+		if filename == "" {
+			filename = fmt.Sprintf("attached-message-%d.eml", len(content.attachments)+1)
+		}
+		content.attachments = append(content.attachments, models.EmbeddedFile{Name: filename, Data: data})
+	case disposition == "attachment" || filename != "":
+		if filename == "" {
+			filename = fmt.Sprintf("attachment-%d%s", len(content.attachments)+1, extensionForMediaType(mediaType))
+		}
+		content.attachments = append(content.attachments, models.EmbeddedFile{Name: filename, Data: data})
+	case mediaType == "text/plain":
+		content.plain = append(content.plain, decodeCharset(data, params["charset"]))
+	case mediaType == "text/html":
+		content.html = append(content.html, decodeCharset(data, params["charset"]))
+	}
+
+	return nil
+}
+
+func (e *EmlExtractor) readMultipart(mediaType, boundary string, body io.Reader, content *emailContent, decoder *mime.WordDecoder, depth int) error {
+	if boundary == "" {
+		return fmt.Errorf("multipart section without boundary")
+	}
+
+	reader := multipart.NewReader(body, boundary)
+
+	var alternatives []*emailContent
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		target := content
+		if mediaType == "multipart/alternative" {
+			target = &emailContent{}
+			alternatives = append(alternatives, target)
+		}
+
+		if err := e.readEntity(part.Header, part, target, decoder, depth+1); err != nil {
+			return err
+		}
+	}
+
+	var chosen *emailContent
+	for _, alternative := range alternatives {
+		content.attachments = append(content.attachments, alternative.attachments...)
+		if len(alternative.plain) > 0 && (chosen == nil || len(chosen.plain) == 0) {
+			chosen = alternative
+		} else if chosen == nil && len(alternative.html) > 0 {
+			chosen = alternative
+		}
+	}
+	if chosen != nil {
+		content.plain = append(content.plain, chosen.plain...)
+		content.html = append(content.html, chosen.html...)
+	}
+
+	return nil
+}
+
+func (c *emailContent) bodyText() string {
+	if len(c.plain) > 0 {
+		return strings.TrimSpace(strings.Join(c.plain, "\n\n"))
+	}
+
+	var parts []string
+	for _, document := range c.html {
+		if text, err := htmlToText(strings.NewReader(document)); err == nil {
+			parts = append(parts, text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n\n"))
+}
+
+func decodeTransferEncoding(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+func extensionForMediaType(mediaType string) string {
+	if extensions, err := mime.ExtensionsByType(mediaType); err == nil && len(extensions) > 0 {
+		return extensions[0]
+	}
+	return ".bin"
+}
+
+func buildEmailDocument(filePath string, headers emailHeaders, body string, attachments []models.EmbeddedFile, format string) models.DocumentMetadata {
+	var text strings.Builder
+	metadata := map[string]string{"format": format}
+
+	for _, field := range []struct{ name, value string }{
+		{"From", headers.From},
+		{"To", headers.To},
+		{"Cc", headers.Cc},
+		{"Subject", headers.Subject},
+		{"Date", headers.Date},
+	} {
+		if field.value == "" {
+			continue
+		}
+		text.WriteString(fmt.Sprintf("%s: %s\n", field.name, field.value))
+		metadata[strings.ToLower(field.name)] = field.value
+	}
+
+	text.WriteString("\n" + strings.ReplaceAll(body, "\r\n", "\n") + "\n")
+
+	if len(attachments) > 0 {
+		text.WriteString("\n[Attachments]\n")
+		for _, attachment := range attachments {
+			text.WriteString(attachment.Name + "\n")
+		}
+	}
+	metadata["attachmentCount"] = strconv.Itoa(len(attachments))
+
+	return models.DocumentMetadata{
+		Filename:    filepath.Base(filePath),
+		Text:        text.String(),
+		Metadata:    metadata,
+		Attachments: attachments,
+		Info: &models.DocumentInfo{
+			Title:   headers.Subject,
+			Author:  headers.From,
+			Created: parseDocumentDate(headers.Date),
+		},
+	}
+}
+
+func (e *EmlExtractor) IsSupportedFormat(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".eml"
+}
+
+func (e *EmlExtractor) GetSupportedFormats() []string {
+	return []string{".eml"}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"relatorios/models"
+	"strconv"
 	"strings"
 
 	"github.com/xuri/excelize/v2"
@@ -12,37 +13,86 @@ import (
 type ExcelExtractor struct{}
 
 func (e *ExcelExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	if isCompoundFile(filePath) {
+		sheets, err := readLegacyWorkbook(filePath)
+		if err != nil {
+			return models.DocumentMetadata{}, fmt.Errorf("failed to read Excel 97-2003 workbook: %w", err)
+		}
+
+		return buildSpreadsheetDocument(filePath, sheets, "biff8"), nil
+	}
+
 	f, err := excelize.OpenFile(filePath)
 	if err != nil {
 		return models.DocumentMetadata{}, fmt.Errorf("failed to open Excel file: %w", err)
 	}
 	defer f.Close()
 
-	var textContent strings.Builder
-
+	var sheets []sheetData
 	for _, sheetName := range f.GetSheetList() {
-		textContent.WriteString(fmt.Sprintf("\n[Sheet: %s]\n", sheetName))
-
 		rows, err := f.GetRows(sheetName)
 		if err != nil {
 			return models.DocumentMetadata{}, fmt.Errorf("failed to read sheet %s: %w", sheetName, err)
 		}
 
-		for _, row := range rows {
+		sheets = append(sheets, sheetData{name: sheetName, rows: rows, cellCount: countCells(rows)})
+	}
+
+	document := buildSpreadsheetDocument(filePath, sheets, "xlsx")
+	readPackageProperties(filePath, document.Info)
+
+	return document, nil
+}
+
+func buildSpreadsheetDocument(filePath string, sheets []sheetData, format string) models.DocumentMetadata {
+	var textContent strings.Builder
+	structure := &models.SpreadsheetStructure{}
+	metadata := map[string]string{
+		"format":     format,
+		"sheetCount": strconv.Itoa(len(sheets)),
+	}
+
+	totalCells := 0
+	for _, sheet := range sheets {
+		textContent.WriteString(fmt.Sprintf("\n[Sheet: %s]\n", sheet.name))
+
+		for _, row := range sheet.rows {
 			if len(row) > 0 {
 				textContent.WriteString(strings.Join(row, " | ") + "\n")
 			}
 		}
+
+		sheetStructure := models.NewSheetStructure(sheet.name, sheet.rows)
+		sheetStructure.Cells = sheet.cells
+		structure.Sheets = append(structure.Sheets, sheetStructure)
+		metadata["cells."+sheet.name] = strconv.Itoa(sheet.cellCount)
+		totalCells += sheet.cellCount
 	}
+	metadata["cellCount"] = strconv.Itoa(totalCells)
 
 	return models.DocumentMetadata{
-		Filename: filepath.Base(filePath),
-		Text:     textContent.String(),
-	}, nil
+		Filename:  filepath.Base(filePath),
+		Text:      textContent.String(),
+		Structure: structure,
+		Metadata:  metadata,
+		Info:      &models.DocumentInfo{SheetCount: len(sheets)},
+	}
+}
+
+func countCells(rows [][]string) int {
+	count := 0
+	for _, row := range rows {
+		for _, cell := range row {
+			if cell != "" {
+				count++
+			}
+		}
+	}
+	return count
 }
 
 func (e *ExcelExtractor) IsSupportedFormat(filePath string) bool {
-	ext := filepath.Ext(filePath)
+	ext := strings.ToLower(filepath.Ext(filePath))
 	return ext == ".xlsx" || ext == ".xls"
 }
 
@@ -0,0 +1,185 @@
+package extractors
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	exifTagDateTime          = 0x0132
+	exifTagExifIFD           = 0x8769
+	exifTagDateTimeOriginal  = 0x9003
+	exifTagDateTimeDigitized = 0x9004
+	exifTagOffsetTime        = 0x9010
+	exifTagOffsetOriginal    = 0x9011
+	exifTagOffsetDigitized   = 0x9012
+	exifTypeASCII            = 2
+	exifTypeLong             = 4
+	exifDateLayout           = "2006:01:02 15:04:05"
+	exifOffsetLayout         = "-07:00"
+	maxExifFileSize          = 64 << 20
+)
+
+var exifDateOffsets = map[uint16]uint16{
+	exifTagDateTimeOriginal:  exifTagOffsetOriginal,
+	exifTagDateTimeDigitized: exifTagOffsetDigitized,
+	exifTagDateTime:          exifTagOffsetTime,
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+func readExifDate(filePath string) *time.Time {
+	info, err := os.Stat(filePath)
+	if err != nil || info.Size() > maxExifFileSize {
+		return nil
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+
+	tiff := data
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8}):
+		tiff = jpegExifSegment(data)
+	case bytes.HasPrefix(data, pngSignature):
+		tiff = pngExifChunk(data)
+	}
+	if tiff == nil {
+		return nil
+	}
+
+	return exifDate(readExifTags(tiff))
+}
+
+func exifDate(tags map[uint16]string) *time.Time {
+	for _, tag := range []uint16{exifTagDateTimeOriginal, exifTagDateTimeDigitized, exifTagDateTime} {
+		value, ok := tags[tag]
+		if !ok {
+			continue
+		}
+
+		location := time.Local
+		if offset, err := time.Parse(exifOffsetLayout, tags[exifDateOffsets[tag]]); err == nil {
+			_, seconds := offset.Zone()
+			location = time.FixedZone("", seconds)
+		}
+
+		if parsed, err := time.ParseInLocation(exifDateLayout, value, location); err == nil {
+			return &parsed
+		}
+	}
+
+	return nil
+}
+
+func pngExifChunk(data []byte) []byte {
+	position := len(pngSignature)
+	for position+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[position:]))
+		chunkType := string(data[position+4 : position+8])
+		end := position + 8 + length
+		if length < 0 || end+4 > len(data) {
+			return nil
+		}
+
+		switch chunkType {
+		case "eXIf":
+			return data[position+8 : end]
+		case "IEND":
+			return nil
+		}
+
+		position = end + 4
+	}
+
+	return nil
+}
+
+func jpegExifSegment(data []byte) []byte {
+	position := 2
+	for position+4 <= len(data) {
+		if data[position] != 0xFF {
+			return nil
+		}
+
+		marker := data[position+1]
+		if marker == 0xD9 || marker == 0xDA {
+			return nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[position+2:]))
+		end := position + 2 + length
+		if length < 2 || end > len(data) {
+			return nil
+		}
+
+		segment := data[position+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+
+		position = end
+	}
+
+	return nil
+}
+
+func readExifTags(tiff []byte) map[uint16]string {
+	if len(tiff) < 8 {
+		return nil
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil
+	}
+
+	tags := make(map[uint16]string)
+	exifOffset := readExifIFD(tiff, order, order.Uint32(tiff[4:]), tags)
+	if exifOffset > 0 {
+		readExifIFD(tiff, order, exifOffset, tags)
+	}
+
+	return tags
+}
+
+func readExifIFD(tiff []byte, order binary.ByteOrder, offset uint32, tags map[uint16]string) uint32 {
+	if int(offset)+2 > len(tiff) {
+		return 0
+	}
+
+	exifOffset := uint32(0)
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := int(offset) + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+
+		tag := order.Uint16(tiff[entry:])
+		valueType := order.Uint16(tiff[entry+2:])
+		valueCount := order.Uint32(tiff[entry+4:])
+
+		switch {
+		case tag == exifTagExifIFD && valueType == exifTypeLong:
+			exifOffset = order.Uint32(tiff[entry+8:])
+		case valueType == exifTypeASCII && valueCount > 4:
+			start := int(order.Uint32(tiff[entry+8:]))
+			if start+int(valueCount) <= len(tiff) {
+				tags[tag] = strings.TrimRight(string(tiff[start:start+int(valueCount)]), "\x00 ")
+			}
+		}
+	}
+
+	return exifOffset
+}
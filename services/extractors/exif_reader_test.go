@@ -0,0 +1,244 @@
+package extractors
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type exifEntry struct {
+	tag   uint16
+	value string
+}
+
+func buildTIFF(order binary.AppendByteOrder, ifd0 []exifEntry, exif []exifEntry) []byte {
+	ifdSize := func(count int) int { return 2 + count*12 + 4 }
+
+	ifd0Count := len(ifd0)
+	if len(exif) > 0 {
+		ifd0Count++
+	}
+	exifOffset := 8 + ifdSize(ifd0Count)
+	dataOffset := exifOffset
+	if len(exif) > 0 {
+		dataOffset += ifdSize(len(exif))
+	}
+
+	tiff := []byte("II")
+	if order.String() == binary.BigEndian.String() {
+		tiff = []byte("MM")
+	}
+	tiff = order.AppendUint16(tiff, 42)
+	tiff = order.AppendUint32(tiff, 8)
+
+	var data []byte
+	writeIFD := func(entries []exifEntry, pointer bool) {
+		count := len(entries)
+		if pointer {
+			count++
+		}
+		tiff = order.AppendUint16(tiff, uint16(count))
+		for _, entry := range entries {
+			value := append([]byte(entry.value), 0)
+			tiff = order.AppendUint16(tiff, entry.tag)
+			tiff = order.AppendUint16(tiff, exifTypeASCII)
+			tiff = order.AppendUint32(tiff, uint32(len(value)))
+			tiff = order.AppendUint32(tiff, uint32(dataOffset+len(data)))
+			data = append(data, value...)
+		}
+		if pointer {
+			tiff = order.AppendUint16(tiff, exifTagExifIFD)
+			tiff = order.AppendUint16(tiff, exifTypeLong)
+			tiff = order.AppendUint32(tiff, 1)
+			tiff = order.AppendUint32(tiff, uint32(exifOffset))
+		}
+		tiff = order.AppendUint32(tiff, 0)
+	}
+
+	writeIFD(ifd0, len(exif) > 0)
+	if len(exif) > 0 {
+		writeIFD(exif, false)
+	}
+
+	return append(tiff, data...)
+}
+
+func TestReadExifTags(t *testing.T) {
+	ifd0 := []exifEntry{{exifTagDateTime, "2024:03:16 08:00:00"}}
+	exif := []exifEntry{
+		{exifTagDateTimeOriginal, "2024:03:15 10:30:00"},
+		{exifTagOffsetOriginal, "-03:00"},
+	}
+
+	for _, order := range []binary.AppendByteOrder{binary.LittleEndian, binary.BigEndian} {
+		tags := readExifTags(buildTIFF(order, ifd0, exif))
+		want := map[uint16]string{
+			exifTagDateTime:         "2024:03:16 08:00:00",
+			exifTagDateTimeOriginal: "2024:03:15 10:30:00",
+			exifTagOffsetOriginal:   "-03:00",
+		}
+		for tag, value := range want {
+			if tags[tag] != value {
+				t.Errorf("%v: tag %#x = %q, want %q", order, tag, tags[tag], value)
+			}
+		}
+	}
+}
+
+func TestReadExifTagsRejectsDamagedData(t *testing.T) {
+	valid := buildTIFF(binary.LittleEndian,
+		[]exifEntry{{exifTagDateTime, "2024:03:16 08:00:00"}},
+		[]exifEntry{{exifTagDateTimeOriginal, "2024:03:15 10:30:00"}},
+	)
+
+	outOfRange := append([]byte{}, valid...)
+	binary.LittleEndian.PutUint32(outOfRange[4:], 0xFFFFFFF0)
+
+	hugeCount := append([]byte{}, valid...)
+	binary.LittleEndian.PutUint16(hugeCount[8:], 0xFFFF)
+
+	valuePastEnd := append([]byte{}, valid...)
+	binary.LittleEndian.PutUint32(valuePastEnd[8+2+8:], uint32(len(valid)-4))
+
+	tests := []struct {
+		name         string
+		tiff         []byte
+		wantDateTime bool
+	}{
+		{"empty", nil, false},
+		{"unknown byte order", append([]byte("XX"), valid[2:]...), false},
+		{"ifd offset out of range", outOfRange, false},
+		{"entry count past the end", hugeCount, true},
+		{"value past the end", valuePastEnd, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tags := readExifTags(test.tiff)
+			if got := tags[exifTagDateTime] != ""; got != test.wantDateTime {
+				t.Errorf("tags = %q, want DateTime read: %v", tags, test.wantDateTime)
+			}
+		})
+	}
+
+	for size := range valid {
+		readExifTags(valid[:size])
+	}
+}
+
+func TestExifDate(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   map[uint16]string
+		want   string
+		offset int
+		local  bool
+	}{
+		{
+			name:  "original without offset is local time",
+			tags:  map[uint16]string{exifTagDateTimeOriginal: "2024:03:15 10:30:00", exifTagDateTime: "2024:03:16 08:00:00"},
+			want:  "2024-03-15 10:30:00",
+			local: true,
+		},
+		{
+			name:   "original with offset",
+			tags:   map[uint16]string{exifTagDateTimeOriginal: "2024:03:15 10:30:00", exifTagOffsetOriginal: "-03:00"},
+			want:   "2024-03-15 10:30:00",
+			offset: -3 * 3600,
+		},
+		{
+			name:   "falls back to digitized and modification time",
+			tags:   map[uint16]string{exifTagDateTimeOriginal: "0000:00:00 00:00:00", exifTagDateTime: "2024:03:16 08:00:00", exifTagOffsetTime: "+05:30"},
+			want:   "2024-03-16 08:00:00",
+			offset: 5*3600 + 30*60,
+		},
+		{
+			name: "no date",
+			tags: map[uint16]string{exifTagOffsetTime: "+01:00"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := exifDate(test.tags)
+			if test.want == "" {
+				if got != nil {
+					t.Errorf("exifDate() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("exifDate() = nil, want %s", test.want)
+			}
+			if formatted := got.Format("2006-01-02 15:04:05"); formatted != test.want {
+				t.Errorf("exifDate() = %s, want %s", formatted, test.want)
+			}
+			if test.local {
+				if got.Location() != time.Local {
+					t.Errorf("location = %v, want local time", got.Location())
+				}
+			} else if _, offset := got.Zone(); offset != test.offset {
+				t.Errorf("offset = %d, want %d", offset, test.offset)
+			}
+		})
+	}
+}
+
+func TestReadExifDateFromImages(t *testing.T) {
+	tiff := buildTIFF(binary.BigEndian, nil, []exifEntry{
+		{exifTagDateTimeOriginal, "2024:03:15 10:30:00"},
+		{exifTagOffsetOriginal, "+01:00"},
+	})
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	jpeg := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x04, 0x00, 0x00, 0xFF, 0xE1}
+	jpeg = binary.BigEndian.AppendUint16(jpeg, uint16(len(segment)+2))
+	jpeg = append(append(jpeg, segment...), 0xFF, 0xD9)
+
+	chunk := func(chunkType string, data []byte) []byte {
+		chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+		chunk = append(append(chunk, chunkType...), data...)
+		return append(chunk, 0, 0, 0, 0)
+	}
+	png := append([]byte{}, pngSignature...)
+	png = append(png, chunk("IHDR", make([]byte, 13))...)
+	png = append(png, chunk("IDAT", []byte{1, 2, 3})...)
+	png = append(png, chunk("eXIf", tiff)...)
+	png = append(png, chunk("IEND", nil)...)
+
+	brokenPNG := append([]byte{}, png...)
+	binary.BigEndian.PutUint32(brokenPNG[len(pngSignature):], 0xFFFFFFF0)
+
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"photo.jpg", jpeg, true},
+		{"screenshot.png", png, true},
+		{"scan.tif", tiff, true},
+		{"broken.png", brokenPNG, false},
+		{"truncated.jpg", jpeg[:20], false},
+	}
+
+	dir := t.TempDir()
+	for _, test := range tests {
+		path := filepath.Join(dir, test.name)
+		if err := os.WriteFile(path, test.data, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		got := readExifDate(path)
+		if !test.want {
+			if got != nil {
+				t.Errorf("%s: date = %v, want nil", test.name, got)
+			}
+			continue
+		}
+		if got == nil || got.UTC().Format(time.RFC3339) != "2024-03-15T09:30:00Z" {
+			t.Errorf("%s: date = %v, want 2024-03-15 10:30 +01:00", test.name, got)
+		}
+	}
+}
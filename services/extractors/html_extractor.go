@@ -0,0 +1,104 @@
+package extractors
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+var htmlMetaNames = map[string]bool{
+	"description":    true,
+	"keywords":       true,
+	"author":         true,
+	"generator":      true,
+	"og:title":       true,
+	"og:description": true,
+	"og:site_name":   true,
+	"og:type":        true,
+}
+
+type HtmlExtractor struct{}
+
+func (e *HtmlExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return models.DocumentMetadata{}, err
+	}
+
+	reader, err := charset.NewReader(bytes.NewReader(data), "text/html")
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to detect HTML encoding: %w", err)
+	}
+
+	root, err := html.Parse(reader)
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	metadata := map[string]string{"format": "html"}
+	e.collectHead(root, metadata)
+
+	var text strings.Builder
+	if title := metadata["title"]; title != "" {
+		text.WriteString(title + "\n\n")
+	}
+	if description := metadata["meta.description"]; description != "" {
+		text.WriteString(description + "\n\n")
+	}
+	text.WriteString(htmlNodeText(root))
+
+	return models.DocumentMetadata{
+		Filename: filepath.Base(filePath),
+		Text:     text.String(),
+		Metadata: metadata,
+		Info: &models.DocumentInfo{
+			Title:  metadata["title"],
+			Author: metadata["meta.author"],
+		},
+	}, nil
+}
+
+func (e *HtmlExtractor) collectHead(node *html.Node, metadata map[string]string) {
+	if node.Type == html.ElementNode {
+		switch node.Data {
+		case "title":
+			if metadata["title"] == "" {
+				metadata["title"] = strings.Join(strings.Fields(htmlNodeText(node)), " ")
+			}
+		case "meta":
+			name, content := "", ""
+			for _, attr := range node.Attr {
+				switch strings.ToLower(attr.Key) {
+				case "name", "property":
+					name = strings.ToLower(attr.Val)
+				case "content":
+					content = strings.TrimSpace(attr.Val)
+				}
+			}
+			if htmlMetaNames[name] && content != "" {
+				metadata["meta."+name] = content
+			}
+		case "body":
+			return
+		}
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		e.collectHead(child, metadata)
+	}
+}
+
+func (e *HtmlExtractor) IsSupportedFormat(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return ext == ".html" || ext == ".htm"
+}
+
+func (e *HtmlExtractor) GetSupportedFormats() []string {
+	return []string{".html", ".htm"}
+}
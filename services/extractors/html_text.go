@@ -0,0 +1,81 @@
+package extractors
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var htmlBlockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "main": true, "nav": true, "ol": true,
+	"p": true, "pre": true, "section": true, "table": true, "tr": true, "ul": true,
+}
+
+var htmlSkippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true,
+}
+
+func htmlToText(r io.Reader) (string, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return "", err
+	}
+
+	return htmlNodeText(root), nil
+}
+
+func htmlNodeText(root *html.Node) string {
+	var builder strings.Builder
+	writeHTMLText(&builder, root)
+
+	lines := strings.Split(builder.String(), "\n")
+	cleaned := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		line = strings.Trim(line, "| ")
+		if line != "" {
+			cleaned = append(cleaned, line)
+		}
+	}
+
+	return strings.Join(cleaned, "\n")
+}
+
+func writeHTMLText(builder *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		builder.WriteString(node.Data)
+		return
+	case html.ElementNode:
+		if htmlSkippedElements[node.Data] {
+			return
+		}
+		if node.Data == "img" {
+			for _, attr := range node.Attr {
+				if attr.Key == "alt" && strings.TrimSpace(attr.Val) != "" {
+					builder.WriteString(" " + attr.Val + " ")
+				}
+			}
+		}
+	}
+
+	isBlock := node.Type == html.ElementNode && htmlBlockElements[node.Data]
+	if isBlock {
+		builder.WriteString("\n")
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeHTMLText(builder, child)
+	}
+
+	switch {
+	case isBlock:
+		builder.WriteString("\n")
+	case node.Type == html.ElementNode && (node.Data == "td" || node.Data == "th"):
+		builder.WriteString(" | ")
+	}
+}
@@ -1,9 +1,8 @@
 package extractors
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -12,13 +11,19 @@ import (
 	"relatorios/models"
 )
 
-type ImageExtractor struct{}
+type ImageExtractor struct {
+	ocr *OCREngine
+}
 
 func (e *ImageExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	return e.ExtractTextWithOCR(filePath, e.ocr.GetOptions())
+}
+
+func (e *ImageExtractor) ExtractTextWithOCR(filePath string, options models.OCROptions) (models.DocumentMetadata, error) {
 	osType := runtime.GOOS
+	info := &models.DocumentInfo{DateTaken: readExifDate(filePath)}
 
-	tesseractPath, tesseractErr := e.findTesseract(osType)
-	if tesseractErr != nil {
+	if _, tesseractErr := e.ocr.Tesseract(); tesseractErr != nil {
 		installInstructions := e.getInstallInstructions(osType)
 
 		fmt.Println("WARNING: Tesseract OCR not found. " + installInstructions)
@@ -26,82 +31,31 @@ func (e *ImageExtractor) ExtractText(filePath string) (models.DocumentMetadata,
 		return models.DocumentMetadata{
 			Filename: filepath.Base(filePath),
 			Text:     fmt.Sprintf("Image: %s (OCR not available)\n\n%s", filepath.Base(filePath), installInstructions),
+			Info:     info,
+			Warnings: []models.ExtractionWarning{ocrWarning(0, "Tesseract OCR not found")},
 		}, nil
 	}
 
-	tempDir, err := os.MkdirTemp("", "tesseract-output")
-	if err != nil {
-		return models.DocumentMetadata{}, fmt.Errorf("failed to create temporary directory: %w", err)
-	}
-	defer os.RemoveAll(tempDir)
-
-	outputPrefix := filepath.Join(tempDir, "output")
-
-	cmd := exec.Command(tesseractPath, filePath, outputPrefix, "-l", "por")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		if strings.Contains(string(output), "Error opening data file") ||
-			strings.Contains(string(output), "Failed loading language 'por'") {
-			fmt.Println("WARNING: Portuguese language package not found. Using default English.")
-			cmd = exec.Command(tesseractPath, filePath, outputPrefix)
-			output, err = cmd.CombinedOutput()
-			if err != nil {
-				return models.DocumentMetadata{}, fmt.Errorf("failed to execute OCR: %w\nOutput: %s", err, string(output))
-			}
-		} else {
-			return models.DocumentMetadata{}, fmt.Errorf("failed to execute OCR: %w\nOutput: %s", err, string(output))
-		}
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout())
+	defer cancel()
 
-	textBytes, err := os.ReadFile(outputPrefix + ".txt")
+	result, err := e.ocr.Recognize(ctx, filePath, options, options.DPI)
 	if err != nil {
-		return models.DocumentMetadata{}, fmt.Errorf("failed to read OCR output: %w", err)
+		return models.DocumentMetadata{}, err
 	}
 
-	text := e.postprocessText(string(textBytes))
-
-	return models.DocumentMetadata{
+	document := models.DocumentMetadata{
 		Filename: filepath.Base(filePath),
-		Text:     text,
-	}, nil
-}
-
-func (e *ImageExtractor) findTesseract(osType string) (string, error) {
-	tesseractPath, err := exec.LookPath("tesseract")
-	if err == nil {
-		return tesseractPath, nil
+		Text:     e.postprocessText(result.text),
+		Language: result.language,
+		Info:     info,
+		Warnings: result.warnings,
 	}
-
-	var possiblePaths []string
-
-	switch osType {
-	case "windows":
-		possiblePaths = []string{
-			"C:\\Program Files\\Tesseract-OCR\\tesseract.exe",
-			"C:\\Program Files (x86)\\Tesseract-OCR\\tesseract.exe",
-			"C:\\Tesseract-OCR\\tesseract.exe",
-		}
-	case "darwin":
-		possiblePaths = []string{
-			"/usr/local/bin/tesseract",
-			"/opt/homebrew/bin/tesseract",
-			"/opt/local/bin/tesseract",
-		}
-	case "linux":
-		possiblePaths = []string{
-			"/usr/bin/tesseract",
-			"/usr/local/bin/tesseract",
-			"/snap/bin/tesseract",
-		}
-	}
-
-	for _, path := range possiblePaths {
-		if _, err := os.Stat(path); err == nil {
-			return path, nil
-		}
+	if strings.TrimSpace(result.text) != "" {
+		document.SetPages([]models.DocumentPage{models.NewDocumentPage(1, document.Text)})
 	}
 
-	return "", fmt.Errorf("tesseract not found")
+	return document, nil
 }
 
 func (e *ImageExtractor) getInstallInstructions(osType string) string {
@@ -135,14 +89,16 @@ func (e *ImageExtractor) postprocessText(text string) string {
 		return "Could not extract text from this image."
 	}
 
-	re := regexp.MustCompile(`\s+`)
-	text = re.ReplaceAllString(text, " ")
+	re := regexp.MustCompile(`[ \t\f\v]+`)
 
-	text = strings.ReplaceAll(text, "\r\n", " ")
-	text = strings.ReplaceAll(text, "\n", " ")
-	text = strings.ReplaceAll(text, "\r", " ")
+	lines := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(re.ReplaceAllString(line, " ")); line != "" {
+			lines = append(lines, line)
+		}
+	}
 
-	return strings.TrimSpace(text)
+	return strings.Join(lines, "\n")
 }
 
 func (e *ImageExtractor) IsSupportedFormat(filePath string) bool {
@@ -0,0 +1,588 @@
+package extractors
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"relatorios/models"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	biffBOF        = 0x0809
+	biffEOF        = 0x000A
+	biffFilePass   = 0x002F
+	biffBoundSheet = 0x0085
+	biffSST        = 0x00FC
+	biffContinue   = 0x003C
+	biffLabelSST   = 0x00FD
+	biffLabel      = 0x0204
+	biffNumber     = 0x0203
+	biffRK         = 0x027E
+	biffMulRK      = 0x00BD
+	biffBoolErr    = 0x0205
+	biffFormula    = 0x0006
+	biffString     = 0x0207
+	biffFormat     = 0x041E
+	biffXF         = 0x00E0
+	biffDateMode   = 0x0022
+
+	biff8Version   = 0x0600
+	worksheetType  = 0x00
+	maxBiffColumns = 256
+	maxSheetCells  = 10000
+	maxExcelSerial = 2958465
+)
+
+var builtInDateFormats = map[uint16]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	27: true, 28: true, 29: true, 30: true, 31: true, 32: true, 33: true, 34: true, 35: true, 36: true,
+	45: true, 46: true, 47: true,
+	50: true, 51: true, 52: true, 53: true, 54: true, 55: true, 56: true, 57: true, 58: true,
+}
+
+type biffRecord struct {
+	typ    uint16
+	offset int
+	data   []byte
+}
+
+type sheetData struct {
+	name      string
+	rows      [][]string
+	cellCount int
+	cells     []models.SpreadsheetCell
+}
+
+type biffStyles struct {
+	dateStyles []bool
+	date1904   bool
+}
+
+func (s biffStyles) isDate(xf int) bool {
+	return xf < len(s.dateStyles) && s.dateStyles[xf]
+}
+
+func readLegacyWorkbook(filePath string) ([]sheetData, error) {
+	streams, err := readCompoundStreams(filePath, func(path string) bool {
+		return path == "Workbook" || path == "Book"
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	workbook, ok := streams["Workbook"]
+	if !ok {
+		if _, isBiff5 := streams["Book"]; isBiff5 {
+			return nil, fmt.Errorf("Excel 5.0/95 workbooks are not supported")
+		}
+		return nil, fmt.Errorf("Workbook stream not found")
+	}
+
+	records, err := parseBiffRecords(workbook)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 || records[0].typ != biffBOF || len(records[0].data) < 2 ||
+		binary.LittleEndian.Uint16(records[0].data) != biff8Version {
+		return nil, fmt.Errorf("only BIFF8 (Excel 97-2003) workbooks are supported")
+	}
+
+	type sheetEntry struct {
+		name   string
+		offset int
+	}
+
+	var sheets []sheetEntry
+	var sharedStrings []string
+	var styles biffStyles
+	var xfFormats []uint16
+	formats := make(map[uint16]string)
+
+	for i := 0; i < len(records); i++ {
+		record := records[i]
+
+		switch record.typ {
+		case biffFilePass:
+			return nil, fmt.Errorf("encrypted workbooks are not supported")
+		case biffBoundSheet:
+			if len(record.data) < 8 || record.data[5] != worksheetType {
+				continue
+			}
+			reader := &biffReader{segments: [][]byte{record.data[6:]}}
+			sheets = append(sheets, sheetEntry{
+				name:   reader.readShortString(),
+				offset: int(binary.LittleEndian.Uint32(record.data)),
+			})
+		case biffSST:
+			segments := [][]byte{record.data}
+			for i+1 < len(records) && records[i+1].typ == biffContinue {
+				i++
+				segments = append(segments, records[i].data)
+			}
+			sharedStrings = readSharedStrings(segments)
+		case biffFormat:
+			if len(record.data) < 5 {
+				continue
+			}
+			reader := &biffReader{segments: [][]byte{record.data[2:]}}
+			formats[binary.LittleEndian.Uint16(record.data)] = reader.readString()
+		case biffXF:
+			if len(record.data) < 4 {
+				continue
+			}
+			xfFormats = append(xfFormats, binary.LittleEndian.Uint16(record.data[2:]))
+		case biffDateMode:
+			if len(record.data) >= 2 {
+				styles.date1904 = binary.LittleEndian.Uint16(record.data) == 1
+			}
+		case biffEOF:
+			i = len(records)
+		}
+	}
+
+	for _, format := range xfFormats {
+		styles.dateStyles = append(styles.dateStyles, isDateFormat(format, formats))
+	}
+
+	positions := make(map[int]int)
+	for i, record := range records {
+		positions[record.offset] = i
+	}
+
+	result := make([]sheetData, 0, len(sheets))
+	for _, sheet := range sheets {
+		start, ok := positions[sheet.offset]
+		if !ok {
+			return nil, fmt.Errorf("sheet %s not found in workbook stream", sheet.name)
+		}
+
+		result = append(result, readLegacySheet(sheet.name, records[start+1:], sharedStrings, styles))
+	}
+
+	return result, nil
+}
+
+func parseBiffRecords(stream []byte) ([]biffRecord, error) {
+	var records []biffRecord
+
+	for offset := 0; offset+4 <= len(stream); {
+		typ := binary.LittleEndian.Uint16(stream[offset:])
+		length := int(binary.LittleEndian.Uint16(stream[offset+2:]))
+
+		if offset+4+length > len(stream) {
+			return nil, fmt.Errorf("truncated record 0x%04X at offset %d", typ, offset)
+		}
+
+		records = append(records, biffRecord{
+			typ:    typ,
+			offset: offset,
+			data:   stream[offset+4 : offset+4+length],
+		})
+		offset += 4 + length
+	}
+
+	return records, nil
+}
+
+func readLegacySheet(name string, records []biffRecord, sharedStrings []string, styles biffStyles) sheetData {
+	cells := make(map[int]map[int]string)
+	cellCount := 0
+	var details []models.SpreadsheetCell
+
+	setTypedCell := func(row, col int, value string, cellType string, formula bool) {
+		if col >= maxBiffColumns {
+			return
+		}
+		if cells[row] == nil {
+			cells[row] = make(map[int]string)
+		}
+		cells[row][col] = value
+		cellCount++
+
+		if len(details) < maxSheetCells {
+			address, _ := excelize.CoordinatesToCellName(col+1, row+1)
+			details = append(details, models.SpreadsheetCell{Address: address, Type: cellType, Value: value, Formula: formula})
+		}
+	}
+	setCell := func(row, col int, value string) {
+		setTypedCell(row, col, value, "string", false)
+	}
+	setNumber := func(row, col, xf int, value float64, formula bool) {
+		if styles.isDate(xf) {
+			if date, ok := formatBiffDate(value, styles.date1904); ok {
+				setTypedCell(row, col, date, "date", formula)
+				return
+			}
+		}
+		setTypedCell(row, col, formatBiffNumber(value), "number", formula)
+	}
+
+	for i, record := range records {
+		data := record.data
+
+		switch record.typ {
+		case biffEOF:
+			return buildLegacySheet(name, cells, cellCount, details)
+		case biffLabelSST:
+			if len(data) < 10 {
+				continue
+			}
+			index := int(binary.LittleEndian.Uint32(data[6:]))
+			if index < len(sharedStrings) {
+				setCell(biffRow(data), biffColumn(data), sharedStrings[index])
+			}
+		case biffLabel:
+			if len(data) < 9 {
+				continue
+			}
+			reader := &biffReader{segments: [][]byte{data[6:]}}
+			setCell(biffRow(data), biffColumn(data), reader.readString())
+		case biffNumber:
+			if len(data) < 14 {
+				continue
+			}
+			value := math.Float64frombits(binary.LittleEndian.Uint64(data[6:]))
+			setNumber(biffRow(data), biffColumn(data), biffXFIndex(data), value, false)
+		case biffRK:
+			if len(data) < 10 {
+				continue
+			}
+			setNumber(biffRow(data), biffColumn(data), biffXFIndex(data), decodeRK(binary.LittleEndian.Uint32(data[6:])), false)
+		case biffMulRK:
+			if len(data) < 6 {
+				continue
+			}
+			row := biffRow(data)
+			firstColumn := biffColumn(data)
+			for j := 0; 4+j*6+6 <= len(data)-2; j++ {
+				xf := int(binary.LittleEndian.Uint16(data[4+j*6:]))
+				rk := binary.LittleEndian.Uint32(data[4+j*6+2:])
+				setNumber(row, firstColumn+j, xf, decodeRK(rk), false)
+			}
+		case biffBoolErr:
+			if len(data) < 8 {
+				continue
+			}
+			if data[7] == 0 {
+				setTypedCell(biffRow(data), biffColumn(data), strconv.FormatBool(data[6] != 0), "boolean", false)
+			} else {
+				setTypedCell(biffRow(data), biffColumn(data), "#ERROR", "error", false)
+			}
+		case biffFormula:
+			if len(data) < 14 {
+				continue
+			}
+			result := data[6:14]
+			if result[6] != 0xFF || result[7] != 0xFF {
+				setNumber(biffRow(data), biffColumn(data), biffXFIndex(data), math.Float64frombits(binary.LittleEndian.Uint64(result)), true)
+				continue
+			}
+
+			switch result[0] {
+			case 0:
+				if i+1 < len(records) && records[i+1].typ == biffString {
+					reader := &biffReader{segments: [][]byte{records[i+1].data}}
+					setTypedCell(biffRow(data), biffColumn(data), reader.readString(), "string", true)
+				}
+			case 1:
+				setTypedCell(biffRow(data), biffColumn(data), strconv.FormatBool(result[2] != 0), "boolean", true)
+			case 2:
+				setTypedCell(biffRow(data), biffColumn(data), "#ERROR", "error", true)
+			}
+		}
+	}
+
+	return buildLegacySheet(name, cells, cellCount, details)
+}
+
+func buildLegacySheet(name string, cells map[int]map[int]string, cellCount int, details []models.SpreadsheetCell) sheetData {
+	rowNumbers := make([]int, 0, len(cells))
+	for row := range cells {
+		rowNumbers = append(rowNumbers, row)
+	}
+	sort.Ints(rowNumbers)
+
+	rows := make([][]string, 0, len(rowNumbers))
+	for _, rowNumber := range rowNumbers {
+		lastColumn := -1
+		for col := range cells[rowNumber] {
+			if col > lastColumn {
+				lastColumn = col
+			}
+		}
+
+		row := make([]string, lastColumn+1)
+		for col, value := range cells[rowNumber] {
+			row[col] = value
+		}
+		rows = append(rows, row)
+	}
+
+	return sheetData{name: name, rows: rows, cellCount: cellCount, cells: details}
+}
+
+func biffRow(data []byte) int {
+	return int(binary.LittleEndian.Uint16(data))
+}
+
+func biffColumn(data []byte) int {
+	return int(binary.LittleEndian.Uint16(data[2:]))
+}
+
+func biffXFIndex(data []byte) int {
+	return int(binary.LittleEndian.Uint16(data[4:]))
+}
+
+func isDateFormat(id uint16, formats map[uint16]string) bool {
+	code, custom := formats[id]
+	if !custom {
+		return builtInDateFormats[id]
+	}
+
+	section := strings.ToLower(strings.SplitN(code, ";", 2)[0])
+	inQuotes := false
+
+	for i := 0; i < len(section); i++ {
+		c := section[i]
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case c == '\\' || c == '_' || c == '*':
+			i++
+		case c == '[':
+			end := strings.IndexByte(section[i:], ']')
+			if end < 0 {
+				return false
+			}
+			if end > 1 && strings.Trim(section[i+1:i+end], "hms") == "" {
+				return true
+			}
+			i += end
+		case c == 'd' || c == 'm' || c == 'y' || c == 'h' || c == 's':
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatBiffDate(serial float64, date1904 bool) (string, bool) {
+	if serial < 0 || serial > maxExcelSerial || math.IsNaN(serial) {
+		return "", false
+	}
+
+	base := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		base = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	} else if serial < 61 {
+		base = base.AddDate(0, 0, 1)
+	}
+
+	days := math.Floor(serial)
+	seconds := math.Round((serial - days) * 86400)
+	value := base.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second)
+
+	switch {
+	case days == 0 && !date1904:
+		return value.Format("15:04:05"), true
+	case seconds == 0:
+		return value.Format("2006-01-02"), true
+	default:
+		return value.Format("2006-01-02 15:04:05"), true
+	}
+}
+
+func decodeRK(rk uint32) float64 {
+	var value float64
+	if rk&0x02 != 0 {
+		value = float64(int32(rk) >> 2)
+	} else {
+		value = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+
+	if rk&0x01 != 0 {
+		value /= 100
+	}
+
+	return value
+}
+
+func formatBiffNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func readSharedStrings(segments [][]byte) []string {
+	reader := &biffReader{segments: segments}
+	if reader.remaining() < 8 {
+		return nil
+	}
+
+	reader.skip(4)
+	unique := int(reader.readUint32())
+
+	capacity := unique
+	if remaining := reader.remaining() / 3; capacity > remaining {
+		capacity = remaining
+	}
+
+	sharedStrings := make([]string, 0, capacity)
+	for i := 0; i < unique && reader.remaining() > 0; i++ {
+		sharedStrings = append(sharedStrings, reader.readString())
+	}
+
+	return sharedStrings
+}
+
+type biffReader struct {
+	segments [][]byte
+	segment  int
+	position int
+}
+
+func (r *biffReader) remaining() int {
+	total := 0
+	for i := r.segment; i < len(r.segments); i++ {
+		total += len(r.segments[i])
+	}
+	return total - r.position
+}
+
+func (r *biffReader) available() int {
+	if r.segment >= len(r.segments) {
+		return 0
+	}
+	return len(r.segments[r.segment]) - r.position
+}
+
+func (r *biffReader) nextSegment() bool {
+	if r.segment+1 >= len(r.segments) {
+		r.segment = len(r.segments)
+		r.position = 0
+		return false
+	}
+	r.segment++
+	r.position = 0
+	return true
+}
+
+func (r *biffReader) read(n int) []byte {
+	if r.available() == 0 {
+		r.nextSegment()
+	}
+
+	if r.available() < n {
+		r.segment = len(r.segments)
+		return make([]byte, n)
+	}
+
+	data := r.segments[r.segment][r.position : r.position+n]
+	r.position += n
+	return data
+}
+
+func (r *biffReader) skip(n int) {
+	for n > 0 && r.segment < len(r.segments) {
+		if r.available() == 0 {
+			if !r.nextSegment() {
+				return
+			}
+			continue
+		}
+
+		step := n
+		if step > r.available() {
+			step = r.available()
+		}
+		r.position += step
+		n -= step
+	}
+}
+
+func (r *biffReader) readByte() byte {
+	return r.read(1)[0]
+}
+
+func (r *biffReader) readUint16() uint16 {
+	return binary.LittleEndian.Uint16(r.read(2))
+}
+
+func (r *biffReader) readUint32() uint32 {
+	return binary.LittleEndian.Uint32(r.read(4))
+}
+
+func (r *biffReader) readShortString() string {
+	count := int(r.readByte())
+	flags := r.readByte()
+	return r.readCharacters(count, flags&0x01 != 0)
+}
+
+func (r *biffReader) readString() string {
+	count := int(r.readUint16())
+	flags := r.readByte()
+
+	runs := 0
+	if flags&0x08 != 0 {
+		runs = int(r.readUint16())
+	}
+
+	extendedSize := 0
+	if flags&0x04 != 0 {
+		extendedSize = int(r.readUint32())
+	}
+
+	text := r.readCharacters(count, flags&0x01 != 0)
+
+	r.skip(runs * 4)
+	r.skip(extendedSize)
+
+	return text
+}
+
+func (r *biffReader) readCharacters(count int, highByte bool) string {
+	units := make([]uint16, 0, count)
+
+	for count > 0 && r.segment < len(r.segments) {
+		if r.available() == 0 {
+			if !r.nextSegment() {
+				break
+			}
+			highByte = r.readByte()&0x01 != 0
+			continue
+		}
+
+		if highByte {
+			n := r.available() / 2
+			if n > count {
+				n = count
+			}
+			if n == 0 {
+				r.segment = len(r.segments)
+				break
+			}
+			for i := 0; i < n; i++ {
+				units = append(units, r.readUint16())
+			}
+			count -= n
+		} else {
+			n := r.available()
+			if n > count {
+				n = count
+			}
+			for _, b := range r.read(n) {
+				units = append(units, uint16(b))
+			}
+			count -= n
+		}
+	}
+
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,165 @@
+package extractors
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"testing"
+)
+
+func TestExcelExtractorReadsLegacyWorkbook(t *testing.T) {
+	document, err := (&ExcelExtractor{}).ExtractText(filepath.Join("testdata", "notas.xls"))
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+
+	wantText := "\n[Sheet: Notas]\n" +
+		"Cliente | Vencimento | Valor | Pago\n" +
+		"Empresa Exemplo Ltda | 2024-03-15 | 1500.5 | true\n" +
+		"Outro Cliente | 2024-04-18 | 99.9 | sim\n" +
+		"Total | 12:00:00 | 1600.4 | 2024-03-15 18:00:00\n" +
+		"\n[Sheet: Orçamento]\n" +
+		"Cliente\n"
+	if document.Text != wantText {
+		t.Errorf("text = %q, want %q", document.Text, wantText)
+	}
+
+	if document.Metadata["format"] != "biff8" || document.Metadata["sheetCount"] != "2" || document.Metadata["cellCount"] != "17" {
+		t.Errorf("metadata = %v", document.Metadata)
+	}
+
+	if len(document.Structure.Sheets) != 2 {
+		t.Fatalf("sheets = %+v, want 2", document.Structure.Sheets)
+	}
+
+	cells := make(map[string]models.SpreadsheetCell)
+	for _, cell := range document.Structure.Sheets[0].Cells {
+		cells[cell.Address] = cell
+	}
+
+	tests := []models.SpreadsheetCell{
+		{Address: "A1", Type: "string", Value: "Cliente"},
+		{Address: "A2", Type: "string", Value: "Empresa Exemplo Ltda"},
+		{Address: "B2", Type: "date", Value: "2024-03-15"},
+		{Address: "C2", Type: "number", Value: "1500.5"},
+		{Address: "D2", Type: "boolean", Value: "true"},
+		{Address: "B3", Type: "date", Value: "2024-04-18"},
+		{Address: "C3", Type: "number", Value: "99.9"},
+		{Address: "D3", Type: "string", Value: "sim", Formula: true},
+		{Address: "B4", Type: "date", Value: "12:00:00"},
+		{Address: "C4", Type: "number", Value: "1600.4", Formula: true},
+		{Address: "D4", Type: "date", Value: "2024-03-15 18:00:00"},
+	}
+	for _, want := range tests {
+		if got := cells[want.Address]; got != want {
+			t.Errorf("cell %s = %+v, want %+v", want.Address, got, want)
+		}
+	}
+}
+
+func TestReadLegacyWorkbookRejectsDamagedFiles(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "notas.xls"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "damaged.xls")
+
+	for _, size := range []int{0, 100, 512, 1024} {
+		if err := os.WriteFile(path, data[:size], 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readLegacyWorkbook(path); err == nil {
+			t.Errorf("file truncated to %d bytes was accepted", size)
+		}
+	}
+
+	for position := 0; position < len(data); position++ {
+		corrupted := append([]byte{}, data...)
+		corrupted[position] ^= 0xFF
+		if err := os.WriteFile(path, corrupted, 0644); err != nil {
+			t.Fatal(err)
+		}
+		readLegacyWorkbook(path)
+	}
+}
+
+func TestReadSharedStringsBoundsCapacity(t *testing.T) {
+	segment := binary.LittleEndian.AppendUint32(nil, 2)
+	segment = binary.LittleEndian.AppendUint32(segment, 0xFFFFFFFF)
+	segment = append(segment, 2, 0, 0, 'o', 'k')
+
+	sharedStrings := readSharedStrings([][]byte{segment})
+	if len(sharedStrings) != 1 || sharedStrings[0] != "ok" {
+		t.Errorf("shared strings = %q, want only ok", sharedStrings)
+	}
+	if cap(sharedStrings) > len(segment) {
+		t.Errorf("capacity %d was taken from the declared count", cap(sharedStrings))
+	}
+}
+
+func TestIsDateFormat(t *testing.T) {
+	formats := map[uint16]string{
+		164: "dd/mm/yyyy",
+		165: `#,##0.00 "R$"`,
+		166: "[$-416]d/m/yy;@",
+		167: "[h]:mm:ss",
+		168: `0.00" dias"`,
+		169: "[Red]#,##0.00",
+		170: `\d0`,
+		171: "General",
+	}
+
+	tests := []struct {
+		id   uint16
+		want bool
+	}{
+		{0, false},
+		{4, false},
+		{14, true},
+		{22, true},
+		{45, true},
+		{49, false},
+		{164, true},
+		{165, false},
+		{166, true},
+		{167, true},
+		{168, false},
+		{169, false},
+		{170, false},
+		{171, false},
+	}
+
+	for _, test := range tests {
+		if got := isDateFormat(test.id, formats); got != test.want {
+			t.Errorf("isDateFormat(%d) = %v, want %v", test.id, got, test.want)
+		}
+	}
+}
+
+func TestFormatBiffDate(t *testing.T) {
+	tests := []struct {
+		serial   float64
+		date1904 bool
+		want     string
+		ok       bool
+	}{
+		{45366, false, "2024-03-15", true},
+		{45366.75, false, "2024-03-15 18:00:00", true},
+		{0.5, false, "12:00:00", true},
+		{1, false, "1900-01-01", true},
+		{61, false, "1900-03-01", true},
+		{0, true, "1904-01-01", true},
+		{-1, false, "", false},
+		{3e6, false, "", false},
+	}
+
+	for _, test := range tests {
+		got, ok := formatBiffDate(test.serial, test.date1904)
+		if got != test.want || ok != test.ok {
+			t.Errorf("formatBiffDate(%v, %v) = %q, %v; want %q, %v", test.serial, test.date1904, got, ok, test.want, test.ok)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package extractors
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	wordMagic          = 0xA5EC
+	fibFlagWhichTable  = 0x0200
+	fibFlagEncrypted   = 0x0100
+	fibBaseSize        = 32
+	fcClxIndex         = 33
+	pieceCompressedBit = 0x40000000
+	clxtPrc            = 0x01
+	clxtPcdt           = 0x02
+	pieceDescriptorLen = 8
+)
+
+func readLegacyWordText(filePath string) (string, error) {
+	streams, err := readCompoundStreams(filePath, func(path string) bool {
+		return path == "WordDocument" || path == "0Table" || path == "1Table"
+	})
+	if err != nil {
+		return "", err
+	}
+
+	wordDocument, ok := streams["WordDocument"]
+	if !ok {
+		return "", fmt.Errorf("WordDocument stream not found")
+	}
+
+	if len(wordDocument) < fibBaseSize+2 || binary.LittleEndian.Uint16(wordDocument) != wordMagic {
+		return "", fmt.Errorf("invalid Word 97-2003 file information block")
+	}
+
+	flags := binary.LittleEndian.Uint16(wordDocument[0x0A:])
+	if flags&fibFlagEncrypted != 0 {
+		return "", fmt.Errorf("encrypted Word documents are not supported")
+	}
+
+	tableName := "0Table"
+	if flags&fibFlagWhichTable != 0 {
+		tableName = "1Table"
+	}
+
+	table, ok := streams[tableName]
+	if !ok {
+		return "", fmt.Errorf("%s stream not found", tableName)
+	}
+
+	fcClx, lcbClx, err := readClxLocation(wordDocument)
+	if err != nil {
+		return "", err
+	}
+
+	if int(fcClx)+int(lcbClx) > len(table) || lcbClx == 0 {
+		return "", fmt.Errorf("piece table is outside the table stream")
+	}
+
+	pieces, err := readPieceTable(table[fcClx : fcClx+lcbClx])
+	if err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	for _, piece := range pieces {
+		pieceText, err := piece.decode(wordDocument)
+		if err != nil {
+			return "", err
+		}
+		text.WriteString(pieceText)
+	}
+
+	return cleanWordText(text.String()), nil
+}
+
+func readClxLocation(wordDocument []byte) (uint32, uint32, error) {
+	offset := fibBaseSize
+
+	if len(wordDocument) < offset+2 {
+		return 0, 0, fmt.Errorf("truncated file information block")
+	}
+	csw := int(binary.LittleEndian.Uint16(wordDocument[offset:]))
+	offset += 2 + csw*2
+
+	if len(wordDocument) < offset+2 {
+		return 0, 0, fmt.Errorf("truncated file information block")
+	}
+	cslw := int(binary.LittleEndian.Uint16(wordDocument[offset:]))
+	offset += 2 + cslw*4
+
+	if len(wordDocument) < offset+2 {
+		return 0, 0, fmt.Errorf("truncated file information block")
+	}
+	cbRgFcLcb := int(binary.LittleEndian.Uint16(wordDocument[offset:]))
+	offset += 2
+
+	if cbRgFcLcb <= fcClxIndex || len(wordDocument) < offset+(fcClxIndex+1)*8 {
+		return 0, 0, fmt.Errorf("file information block has no piece table location")
+	}
+
+	entry := offset + fcClxIndex*8
+	return binary.LittleEndian.Uint32(wordDocument[entry:]), binary.LittleEndian.Uint32(wordDocument[entry+4:]), nil
+}
+
+type wordPiece struct {
+	charCount  int
+	offset     int
+	compressed bool
+}
+
+func readPieceTable(clx []byte) ([]wordPiece, error) {
+	position := 0
+
+	for position < len(clx) && clx[position] == clxtPrc {
+		if position+3 > len(clx) {
+			return nil, fmt.Errorf("truncated property modifier in piece table")
+		}
+		size := int(binary.LittleEndian.Uint16(clx[position+1:]))
+		if position+3+size > len(clx) {
+			return nil, fmt.Errorf("property modifier exceeds piece table")
+		}
+		position += 3 + size
+	}
+
+	if position+5 > len(clx) || clx[position] != clxtPcdt {
+		return nil, fmt.Errorf("piece table descriptor not found")
+	}
+
+	length := int(binary.LittleEndian.Uint32(clx[position+1:]))
+	position += 5
+
+	if position+length > len(clx) || length < 4 {
+		return nil, fmt.Errorf("truncated piece table")
+	}
+
+	plc := clx[position : position+length]
+	count := (length - 4) / (4 + pieceDescriptorLen)
+	descriptors := 4 * (count + 1)
+
+	pieces := make([]wordPiece, 0, count)
+	for i := 0; i < count; i++ {
+		start := binary.LittleEndian.Uint32(plc[i*4:])
+		end := binary.LittleEndian.Uint32(plc[(i+1)*4:])
+		if end < start {
+			return nil, fmt.Errorf("invalid character positions in piece table")
+		}
+
+		fc := binary.LittleEndian.Uint32(plc[descriptors+i*pieceDescriptorLen+2:])
+		piece := wordPiece{charCount: int(end - start)}
+
+		if fc&pieceCompressedBit != 0 {
+			piece.compressed = true
+			piece.offset = int(fc&^pieceCompressedBit) / 2
+		} else {
+			piece.offset = int(fc)
+		}
+
+		pieces = append(pieces, piece)
+	}
+
+	return pieces, nil
+}
+
+func (p wordPiece) decode(wordDocument []byte) (string, error) {
+	if p.compressed {
+		end := p.offset + p.charCount
+		if end > len(wordDocument) {
+			return "", fmt.Errorf("text piece is outside the WordDocument stream")
+		}
+
+		decoded, err := charmap.Windows1252.NewDecoder().Bytes(wordDocument[p.offset:end])
+		if err != nil {
+			return "", fmt.Errorf("failed to decode text piece: %w", err)
+		}
+		return string(decoded), nil
+	}
+
+	end := p.offset + p.charCount*2
+	if end > len(wordDocument) {
+		return "", fmt.Errorf("text piece is outside the WordDocument stream")
+	}
+
+	units := make([]uint16, p.charCount)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(wordDocument[p.offset+i*2:])
+	}
+
+	return string(utf16.Decode(units)), nil
+}
+
+func cleanWordText(text string) string {
+	var cleaned strings.Builder
+	fieldDepth := 0
+	inInstruction := []bool{}
+
+	for _, r := range text {
+		switch r {
+		case 0x13:
+			fieldDepth++
+			inInstruction = append(inInstruction, true)
+			continue
+		case 0x14:
+			if fieldDepth > 0 {
+				inInstruction[fieldDepth-1] = false
+			}
+			continue
+		case 0x15:
+			if fieldDepth > 0 {
+				fieldDepth--
+				inInstruction = inInstruction[:fieldDepth]
+			}
+			continue
+		}
+
+		if fieldDepth > 0 && inInstruction[fieldDepth-1] {
+			continue
+		}
+
+		switch r {
+		case '\r', 0x0B, 0x0C:
+			cleaned.WriteRune('\n')
+		case 0x07:
+			cleaned.WriteString(" | ")
+		case '\t', '\n':
+			cleaned.WriteRune(r)
+		default:
+			if r >= 0x20 {
+				cleaned.WriteRune(r)
+			}
+		}
+	}
+
+	return cleaned.String()
+}
@@ -0,0 +1,147 @@
+package extractors
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadLegacyWordText(t *testing.T) {
+	text, err := readLegacyWordText(filepath.Join("testdata", "contrato.doc"))
+	if err != nil {
+		t.Fatalf("readLegacyWordText: %v", err)
+	}
+
+	want := "Contrato de prestação de serviços\n" +
+		"Cláusula primeira: o pagamento é mensal.\n" +
+		"Item | Valor |  | Serviço | R$ 1.500,00 |  | \n" +
+		"Assinado em São Paulo.\n"
+	if text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+}
+
+func TestReadLegacyWordTextRejectsDamagedFiles(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "contrato.doc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "damaged.doc")
+
+	for _, size := range []int{0, 8, 511, 512, 1024, 1536, len(data) - 512} {
+		if err := os.WriteFile(path, data[:size], 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readLegacyWordText(path); err == nil {
+			t.Errorf("file truncated to %d bytes was accepted", size)
+		}
+	}
+
+	for position := 0; position < len(data); position++ {
+		corrupted := append([]byte{}, data...)
+		corrupted[position] ^= 0xFF
+		if err := os.WriteFile(path, corrupted, 0644); err != nil {
+			t.Fatal(err)
+		}
+		readLegacyWordText(path)
+	}
+
+	if _, err := readLegacyWordText(filepath.Join("testdata", "oversized_stream.doc")); err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("oversized stream error = %v, want a size error", err)
+	}
+}
+
+func TestReadPieceTable(t *testing.T) {
+	plc := func(cps []uint32, fcs []uint32) []byte {
+		data := []byte{}
+		for _, cp := range cps {
+			data = binary.LittleEndian.AppendUint32(data, cp)
+		}
+		for _, fc := range fcs {
+			descriptor := make([]byte, pieceDescriptorLen)
+			binary.LittleEndian.PutUint32(descriptor[2:], fc)
+			data = append(data, descriptor...)
+		}
+		return data
+	}
+	pcdt := func(plc []byte) []byte {
+		return append(binary.LittleEndian.AppendUint32([]byte{clxtPcdt}, uint32(len(plc))), plc...)
+	}
+	prc := func(size uint16, payload int) []byte {
+		return append(binary.LittleEndian.AppendUint16([]byte{clxtPrc}, size), make([]byte, payload)...)
+	}
+
+	valid := plc([]uint32{0, 10, 25}, []uint32{0x400 | pieceCompressedBit, 0x800})
+
+	tests := []struct {
+		name    string
+		clx     []byte
+		want    []wordPiece
+		wantErr bool
+	}{
+		{
+			name: "compressed and unicode pieces",
+			clx:  pcdt(valid),
+			want: []wordPiece{{charCount: 10, offset: 0x200, compressed: true}, {charCount: 15, offset: 0x800}},
+		},
+		{
+			name: "property modifiers before the pieces",
+			clx:  append(append(prc(2, 2), prc(0, 0)...), pcdt(valid)...),
+			want: []wordPiece{{charCount: 10, offset: 0x200, compressed: true}, {charCount: 15, offset: 0x800}},
+		},
+		{name: "negative property modifier size", clx: append(prc(0xFFFD, 0), pcdt(valid)...), wantErr: true},
+		{name: "property modifier past the end", clx: append(prc(100, 2), pcdt(valid)...), wantErr: true},
+		{name: "truncated property modifier", clx: []byte{clxtPrc, 0x01}, wantErr: true},
+		{name: "missing piece descriptor", clx: prc(2, 2), wantErr: true},
+		{name: "empty", clx: nil, wantErr: true},
+		{name: "truncated piece table", clx: pcdt(valid)[:len(valid)], wantErr: true},
+		{name: "piece table too short", clx: pcdt([]byte{0, 0}), wantErr: true},
+		{name: "decreasing character positions", clx: pcdt(plc([]uint32{10, 0}, []uint32{0})), wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pieces, err := readPieceTable(test.clx)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("readPieceTable() = %+v, want an error", pieces)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readPieceTable: %v", err)
+			}
+
+			if len(pieces) != len(test.want) {
+				t.Fatalf("pieces = %+v, want %+v", pieces, test.want)
+			}
+			for i := range pieces {
+				if pieces[i] != test.want[i] {
+					t.Errorf("piece %d = %+v, want %+v", i, pieces[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func FuzzReadLegacyWordText(f *testing.F) {
+	data, err := os.ReadFile(filepath.Join("testdata", "contrato.doc"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(data)
+
+	dir := f.TempDir()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		path := filepath.Join(dir, "fuzz.doc")
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		readLegacyWordText(path)
+		DetectContentFormat(path)
+	})
+}
@@ -0,0 +1,124 @@
+package extractors
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"relatorios/models"
+	"strings"
+)
+
+var (
+	markdownHeading       = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)\s*#*\s*$`)
+	markdownListMarker    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(\[[ xX]\]\s+)?`)
+	markdownBlockquote    = regexp.MustCompile(`^\s*(>\s?)+`)
+	markdownRule          = regexp.MustCompile(`^\s*([-*_]\s*){3,}$`)
+	markdownTableDivider  = regexp.MustCompile(`^\s*\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+	markdownSetextDivider = regexp.MustCompile(`^\s*(=+|-+)\s*$`)
+	markdownImage         = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLink          = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	markdownReference     = regexp.MustCompile(`^\s*\[[^\]]+\]:\s+\S+.*$`)
+	markdownEmphasis      = []*regexp.Regexp{
+		regexp.MustCompile(`\*\*([^*]+)\*\*`),
+		regexp.MustCompile(`(^|\W)__([^_]+)__(\W|$)`),
+		regexp.MustCompile(`\*([^*\s][^*]*)\*`),
+		regexp.MustCompile(`(^|\W)_([^_]+)_(\W|$)`),
+		regexp.MustCompile(`~~([^~]+)~~`),
+	}
+	markdownInlineCode = regexp.MustCompile("`([^`]*)`")
+	markdownHTMLTag    = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+)
+
+type MarkdownExtractor struct{}
+
+func (e *MarkdownExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return models.DocumentMetadata{}, err
+	}
+
+	content, encoding := decodeText(data)
+	metadata := map[string]string{"format": "markdown", "encoding": encoding}
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	output := make([]string, 0, len(lines))
+	inCodeBlock := false
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			output = append(output, line)
+			continue
+		}
+
+		switch {
+		case markdownTableDivider.MatchString(line) && strings.Contains(line, "|"):
+			continue
+		case markdownSetextDivider.MatchString(line) && i > 0 && strings.TrimSpace(lines[i-1]) != "":
+			if metadata["title"] == "" && strings.HasPrefix(trimmed, "=") {
+				metadata["title"] = e.inline(lines[i-1])
+			}
+			continue
+		case markdownRule.MatchString(line), markdownReference.MatchString(line):
+			continue
+		}
+
+		if match := markdownHeading.FindStringSubmatch(line); match != nil {
+			heading := e.inline(match[1])
+			if metadata["title"] == "" {
+				metadata["title"] = heading
+			}
+			output = append(output, heading)
+			continue
+		}
+
+		line = markdownBlockquote.ReplaceAllString(line, "")
+		line = markdownListMarker.ReplaceAllString(line, "$1")
+
+		if strings.HasPrefix(strings.TrimSpace(line), "|") {
+			cells := strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+			for j, cell := range cells {
+				cells[j] = e.inline(strings.TrimSpace(cell))
+			}
+			output = append(output, strings.Join(cells, " | "))
+			continue
+		}
+
+		output = append(output, e.inline(line))
+	}
+
+	return models.DocumentMetadata{
+		Filename: filepath.Base(filePath),
+		Text:     strings.Join(output, "\n"),
+		Metadata: metadata,
+		Info:     &models.DocumentInfo{Title: metadata["title"]},
+	}, nil
+}
+
+func (e *MarkdownExtractor) inline(text string) string {
+	text = markdownImage.ReplaceAllString(text, "$1")
+	text = markdownLink.ReplaceAllString(text, "$1")
+	text = markdownInlineCode.ReplaceAllString(text, "$1")
+	text = markdownHTMLTag.ReplaceAllString(text, "")
+	for _, emphasis := range markdownEmphasis {
+		if emphasis.NumSubexp() == 3 {
+			text = emphasis.ReplaceAllString(text, "$1$2$3")
+		} else {
+			text = emphasis.ReplaceAllString(text, "$1")
+		}
+	}
+	return strings.TrimRight(text, " ")
+}
+
+func (e *MarkdownExtractor) IsSupportedFormat(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return ext == ".md" || ext == ".markdown"
+}
+
+func (e *MarkdownExtractor) GetSupportedFormats() []string {
+	return []string{".md", ".markdown"}
+}
@@ -0,0 +1,182 @@
+package extractors
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"relatorios/models"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	msgSubject           = "0037"
+	msgSenderName        = "0C1A"
+	msgSenderEmail       = "0C1F"
+	msgSenderSMTPAddress = "5D01"
+	msgDisplayTo         = "0E04"
+	msgDisplayCc         = "0E03"
+	msgBody              = "1000"
+	msgHTMLBody          = "1013"
+	msgAttachData        = "3701"
+	msgAttachLongName    = "3707"
+	msgAttachShortName   = "3704"
+	msgDisplayName       = "3001"
+
+	msgClientSubmitTime = 0x00390040
+	msgDeliveryTime     = 0x0E060040
+	msgFileTimeEpoch    = 116444736000000000
+
+	msgAttachmentPrefix = "__attach_version1.0_#"
+	msgPropertiesStream = "__properties_version1.0"
+	msgTopHeaderSize    = 32
+	msgPropertySize     = 16
+)
+
+type MsgExtractor struct{}
+
+func (e *MsgExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	streams, err := readCompoundStreams(filePath, func(path string) bool {
+		return !strings.HasPrefix(path, "__nameid_version1.0") && !strings.HasPrefix(path, "__recip_version1.0_")
+	})
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to open Outlook message: %w", err)
+	}
+
+	if _, ok := streams[msgPropertiesStream]; !ok {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to open Outlook message: property stream not found")
+	}
+
+	from := msgString(streams, "", msgSenderName)
+	email := msgString(streams, "", msgSenderSMTPAddress)
+	if email == "" {
+		email = msgString(streams, "", msgSenderEmail)
+	}
+	if email != "" && email != from {
+		from = strings.TrimSpace(fmt.Sprintf("%s <%s>", from, email))
+	}
+
+	headers := emailHeaders{
+		From:    from,
+		To:      msgString(streams, "", msgDisplayTo),
+		Cc:      msgString(streams, "", msgDisplayCc),
+		Subject: msgString(streams, "", msgSubject),
+	}
+
+	for _, tag := range []uint32{msgClientSubmitTime, msgDeliveryTime} {
+		if date, ok := msgTime(streams[msgPropertiesStream], tag); ok {
+			headers.Date = date.Format(time.RFC3339)
+			break
+		}
+	}
+
+	body := strings.TrimSpace(msgString(streams, "", msgBody))
+	if body == "" {
+		if htmlBody := msgString(streams, "", msgHTMLBody); htmlBody != "" {
+			if text, err := htmlToText(strings.NewReader(htmlBody)); err == nil {
+				body = text
+			}
+		}
+	}
+
+	attachments, skipped := e.attachments(streams)
+
+	document := buildEmailDocument(filePath, headers, body, attachments, "msg")
+	if skipped > 0 {
+		document.Metadata["skippedAttachments"] = fmt.Sprintf("%d (embedded Outlook items)", skipped)
+	}
+
+	return document, nil
+}
+
+func (e *MsgExtractor) attachments(streams map[string][]byte) ([]models.EmbeddedFile, int) {
+	prefixes := make(map[string]bool)
+	for path := range streams {
+		if strings.HasPrefix(path, msgAttachmentPrefix) {
+			if slash := strings.Index(path, "/"); slash > 0 {
+				prefixes[path[:slash+1]] = true
+			}
+		}
+	}
+
+	ordered := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		ordered = append(ordered, prefix)
+	}
+	sort.Strings(ordered)
+
+	var attachments []models.EmbeddedFile
+	skipped := 0
+
+	for _, prefix := range ordered {
+		data, ok := streams[prefix+"__substg1.0_"+msgAttachData+"0102"]
+		if !ok {
+			skipped++
+			continue
+		}
+
+		name := msgString(streams, prefix, msgAttachLongName)
+		if name == "" {
+			name = msgString(streams, prefix, msgAttachShortName)
+		}
+		if name == "" {
+			name = msgString(streams, prefix, msgDisplayName)
+		}
+		if name == "" {
+			name = fmt.Sprintf("attachment-%d.bin", len(attachments)+1)
+		}
+
+		attachments = append(attachments, models.EmbeddedFile{Name: name, Data: data})
+	}
+
+	return attachments, skipped
+}
+
+func msgString(streams map[string][]byte, prefix, property string) string {
+	base := prefix + "__substg1.0_" + property
+
+	if data, ok := streams[base+"001F"]; ok {
+		units := make([]uint16, 0, len(data)/2)
+		for i := 0; i+1 < len(data); i += 2 {
+			units = append(units, binary.LittleEndian.Uint16(data[i:]))
+		}
+		return strings.TrimRight(string(utf16.Decode(units)), "\x00")
+	}
+
+	if data, ok := streams[base+"001E"]; ok {
+		return strings.TrimRight(decodeWindows1252(data), "\x00")
+	}
+
+	if data, ok := streams[base+"0102"]; ok {
+		return strings.TrimRight(decodeCharset(data, ""), "\x00")
+	}
+
+	return ""
+}
+
+func msgTime(properties []byte, tag uint32) (time.Time, bool) {
+	for offset := msgTopHeaderSize; offset+msgPropertySize <= len(properties); offset += msgPropertySize {
+		if binary.LittleEndian.Uint32(properties[offset:]) != tag {
+			continue
+		}
+
+		fileTime := binary.LittleEndian.Uint64(properties[offset+8:])
+		if fileTime <= msgFileTimeEpoch {
+			return time.Time{}, false
+		}
+
+		return time.Unix(0, int64(fileTime-msgFileTimeEpoch)*100).UTC(), true
+	}
+
+	return time.Time{}, false
+}
+
+func (e *MsgExtractor) IsSupportedFormat(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".msg"
+}
+
+func (e *MsgExtractor) GetSupportedFormats() []string {
+	return []string{".msg"}
+}
@@ -0,0 +1,295 @@
+package extractors
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type nfeParty struct {
+	CNPJ  string `xml:"CNPJ"`
+	CPF   string `xml:"CPF"`
+	Name  string `xml:"xNome"`
+	Trade string `xml:"xFant"`
+	IE    string `xml:"IE"`
+	City  string `xml:"enderEmit>xMun"`
+	State string `xml:"enderEmit>UF"`
+}
+
+func (p nfeParty) document() string {
+	if p.CNPJ != "" {
+		return p.CNPJ
+	}
+	return p.CPF
+}
+
+type nfeRecipient struct {
+	CNPJ  string `xml:"CNPJ"`
+	CPF   string `xml:"CPF"`
+	Name  string `xml:"xNome"`
+	City  string `xml:"enderDest>xMun"`
+	State string `xml:"enderDest>UF"`
+}
+
+type nfeItem struct {
+	Number      string `xml:"nItem,attr"`
+	Code        string `xml:"prod>cProd"`
+	Description string `xml:"prod>xProd"`
+	NCM         string `xml:"prod>NCM"`
+	CFOP        string `xml:"prod>CFOP"`
+	Unit        string `xml:"prod>uCom"`
+	Quantity    string `xml:"prod>qCom"`
+	UnitPrice   string `xml:"prod>vUnCom"`
+	Total       string `xml:"prod>vProd"`
+}
+
+type nfeInfo struct {
+	ID          string       `xml:"Id,attr"`
+	Number      string       `xml:"ide>nNF"`
+	Series      string       `xml:"ide>serie"`
+	Model       string       `xml:"ide>mod"`
+	Operation   string       `xml:"ide>natOp"`
+	IssuedAt    string       `xml:"ide>dhEmi"`
+	IssuedOn    string       `xml:"ide>dEmi"`
+	Emitter     nfeParty     `xml:"emit"`
+	Recipient   nfeRecipient `xml:"dest"`
+	Items       []nfeItem    `xml:"det"`
+	Total       string       `xml:"total>ICMSTot>vNF"`
+	ProductsSum string       `xml:"total>ICMSTot>vProd"`
+	ICMS        string       `xml:"total>ICMSTot>vICMS"`
+	Additional  string       `xml:"infAdic>infCpl"`
+}
+
+type nfeProtocol struct {
+	AccessKey  string `xml:"infProt>chNFe"`
+	Protocol   string `xml:"infProt>nProt"`
+	ReceivedAt string `xml:"infProt>dhRecbto"`
+	Status     string `xml:"infProt>cStat"`
+	Reason     string `xml:"infProt>xMotivo"`
+}
+
+type nfeDocument struct {
+	Info     *nfeInfo     `xml:"infNFe"`
+	Protocol *nfeProtocol `xml:"-"`
+}
+
+type nfeProcess struct {
+	NFe      nfeDocument `xml:"NFe"`
+	Protocol nfeProtocol `xml:"protNFe"`
+}
+
+type NfeExtractor struct{}
+
+func (e *NfeExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return models.DocumentMetadata{}, err
+	}
+
+	root, err := xmlRootName(data)
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to parse XML: %w", err)
+	}
+
+	var document nfeDocument
+	switch root {
+	case "nfeProc":
+		var process nfeProcess
+		if err := decodeXML(data, &process); err != nil {
+			return models.DocumentMetadata{}, fmt.Errorf("failed to parse NF-e: %w", err)
+		}
+		document = process.NFe
+		document.Protocol = &process.Protocol
+	case "NFe":
+		if err := decodeXML(data, &document); err != nil {
+			return models.DocumentMetadata{}, fmt.Errorf("failed to parse NF-e: %w", err)
+		}
+	default:
+		return e.plainXML(filePath, data)
+	}
+
+	if document.Info == nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to parse NF-e: infNFe not found")
+	}
+
+	metadata := e.metadata(document)
+
+	return models.DocumentMetadata{
+		Filename: filepath.Base(filePath),
+		Text:     e.text(document, metadata),
+		Metadata: metadata,
+		Info: &models.DocumentInfo{
+			Author:  metadata["nfe.emitter.name"],
+			Created: parseDocumentDate(metadata["nfe.issueDate"]),
+		},
+	}, nil
+}
+
+func (e *NfeExtractor) metadata(document nfeDocument) map[string]string {
+	info := document.Info
+	metadata := map[string]string{"format": "nfe"}
+
+	set := func(key, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			metadata["nfe."+key] = value
+		}
+	}
+
+	accessKey := strings.TrimPrefix(info.ID, "NFe")
+	if document.Protocol != nil && document.Protocol.AccessKey != "" {
+		accessKey = document.Protocol.AccessKey
+	}
+	set("accessKey", accessKey)
+	set("number", info.Number)
+	set("series", info.Series)
+	set("model", info.Model)
+	set("operation", info.Operation)
+
+	issueDate := info.IssuedAt
+	if issueDate == "" {
+		issueDate = info.IssuedOn
+	}
+	set("issueDate", issueDate)
+
+	set("emitter.cnpj", info.Emitter.document())
+	set("emitter.name", info.Emitter.Name)
+	set("emitter.tradeName", info.Emitter.Trade)
+	set("emitter.city", info.Emitter.City)
+	set("emitter.state", info.Emitter.State)
+	set("recipient.cnpj", info.Recipient.CNPJ)
+	set("recipient.cpf", info.Recipient.CPF)
+	set("recipient.name", info.Recipient.Name)
+
+	set("total", info.Total)
+	set("productsTotal", info.ProductsSum)
+	set("icms", info.ICMS)
+	set("items", strconv.Itoa(len(info.Items)))
+
+	cfops := make(map[string]bool)
+	for _, item := range info.Items {
+		if item.CFOP != "" {
+			cfops[item.CFOP] = true
+		}
+	}
+	codes := make([]string, 0, len(cfops))
+	for code := range cfops {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	set("cfop", strings.Join(codes, ","))
+
+	if document.Protocol != nil {
+		set("protocol", document.Protocol.Protocol)
+		set("status", strings.TrimSpace(document.Protocol.Status+" "+document.Protocol.Reason))
+	}
+
+	return metadata
+}
+
+func (e *NfeExtractor) text(document nfeDocument, metadata map[string]string) string {
+	info := document.Info
+	var text strings.Builder
+
+	line := func(label, value string) {
+		if value != "" {
+			text.WriteString(fmt.Sprintf("%s: %s\n", label, value))
+		}
+	}
+
+	text.WriteString(fmt.Sprintf("Nota Fiscal Eletrônica (NF-e) nº %s série %s\n", info.Number, info.Series))
+	line("Chave de acesso", metadata["nfe.accessKey"])
+	line("Data de emissão", metadata["nfe.issueDate"])
+	line("Natureza da operação", info.Operation)
+	line("Emitente", strings.TrimSpace(fmt.Sprintf("%s CNPJ %s", info.Emitter.Name, info.Emitter.document())))
+	if info.Emitter.City != "" {
+		line("Município do emitente", strings.TrimSpace(info.Emitter.City+" "+info.Emitter.State))
+	}
+	if info.Recipient.Name != "" || info.Recipient.CNPJ != "" || info.Recipient.CPF != "" {
+		recipientDocument := "CNPJ " + info.Recipient.CNPJ
+		if info.Recipient.CNPJ == "" {
+			recipientDocument = "CPF " + info.Recipient.CPF
+		}
+		line("Destinatário", strings.TrimSpace(info.Recipient.Name+" "+recipientDocument))
+	}
+
+	if len(info.Items) > 0 {
+		text.WriteString("\nItens:\n")
+		for _, item := range info.Items {
+			text.WriteString(fmt.Sprintf("%s. %s | %s %s x %s | %s | CFOP %s | NCM %s\n",
+				item.Number, item.Description, item.Quantity, item.Unit, item.UnitPrice, item.Total, item.CFOP, item.NCM))
+		}
+		text.WriteString("\n")
+	}
+
+	line("Valor dos produtos", info.ProductsSum)
+	line("Valor do ICMS", info.ICMS)
+	line("Valor total da nota", info.Total)
+	line("Protocolo de autorização", metadata["nfe.protocol"])
+	line("Informações complementares", strings.TrimSpace(info.Additional))
+
+	return text.String()
+}
+
+func (e *NfeExtractor) plainXML(filePath string, data []byte) (models.DocumentMetadata, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charsetReader
+
+	var lines []string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return models.DocumentMetadata{}, fmt.Errorf("failed to parse XML: %w", err)
+		}
+
+		if chars, ok := token.(xml.CharData); ok {
+			if value := strings.TrimSpace(string(chars)); value != "" {
+				lines = append(lines, value)
+			}
+		}
+	}
+
+	return models.DocumentMetadata{
+		Filename: filepath.Base(filePath),
+		Text:     strings.Join(lines, "\n"),
+		Metadata: map[string]string{"format": "xml"},
+	}, nil
+}
+
+func decodeXML(data []byte, target interface{}) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charsetReader
+	return decoder.Decode(target)
+}
+
+func xmlRootName(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charsetReader
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func (e *NfeExtractor) IsSupportedFormat(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".xml"
+}
+
+func (e *NfeExtractor) GetSupportedFormats() []string {
+	return []string{".xml"}
+}
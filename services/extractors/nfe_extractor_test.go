@@ -0,0 +1,100 @@
+package extractors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNfeExtractorReadsAuthorizedInvoice(t *testing.T) {
+	document, err := (&NfeExtractor{}).ExtractText(filepath.Join("testdata", "nfe_proc.xml"))
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+
+	want := map[string]string{
+		"format":                "nfe",
+		"nfe.accessKey":         "35240312345678000190550010000012341000012345",
+		"nfe.number":            "1234",
+		"nfe.series":            "1",
+		"nfe.model":             "55",
+		"nfe.operation":         "Venda de mercadoria",
+		"nfe.issueDate":         "2024-03-15T10:30:00-03:00",
+		"nfe.emitter.cnpj":      "12345678000190",
+		"nfe.emitter.name":      "Empresa Exemplo Ltda",
+		"nfe.emitter.tradeName": "Exemplo",
+		"nfe.emitter.city":      "São Paulo",
+		"nfe.emitter.state":     "SP",
+		"nfe.recipient.cpf":     "12345678909",
+		"nfe.recipient.name":    "Maria Souza",
+		"nfe.total":             "50.00",
+		"nfe.productsTotal":     "50.00",
+		"nfe.icms":              "9.00",
+		"nfe.items":             "2",
+		"nfe.cfop":              "5102,5405",
+		"nfe.protocol":          "135240000012345",
+		"nfe.status":            "100 Autorizado o uso da NF-e",
+	}
+	for key, value := range want {
+		if document.Metadata[key] != value {
+			t.Errorf("metadata[%q] = %q, want %q", key, document.Metadata[key], value)
+		}
+	}
+	for key := range document.Metadata {
+		if _, ok := want[key]; !ok {
+			t.Errorf("unexpected metadata %q = %q", key, document.Metadata[key])
+		}
+	}
+
+	for _, line := range []string{
+		"Nota Fiscal Eletrônica (NF-e) nº 1234 série 1\n",
+		"Destinatário: Maria Souza CPF 12345678909\n",
+		"1. Caneta azul | 10.0000 UN x 2.5000 | 25.00 | CFOP 5102 | NCM 96081000\n",
+		"Valor total da nota: 50.00\n",
+	} {
+		if !strings.Contains(document.Text, line) {
+			t.Errorf("text does not contain %q:\n%s", line, document.Text)
+		}
+	}
+
+	if document.Info.Author != "Empresa Exemplo Ltda" || document.Info.Created == nil {
+		t.Errorf("info = %+v", document.Info)
+	}
+}
+
+func TestNfeExtractorReadsUnsignedNFeAndPlainXML(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "nfe_proc.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	start := strings.Index(text, "<NFe ")
+	end := strings.Index(text, "</NFe>") + len("</NFe>")
+
+	dir := t.TempDir()
+	nfePath := filepath.Join(dir, "nfe.xml")
+	if err := os.WriteFile(nfePath, []byte(text[start:end]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	document, err := (&NfeExtractor{}).ExtractText(nfePath)
+	if err != nil {
+		t.Fatalf("ExtractText: %v", err)
+	}
+	if document.Metadata["nfe.accessKey"] != "35240312345678000190550010000012341000012345" {
+		t.Errorf("access key = %q, want it taken from the Id attribute", document.Metadata["nfe.accessKey"])
+	}
+	if _, ok := document.Metadata["nfe.protocol"]; ok {
+		t.Errorf("unsigned NF-e reported protocol %q", document.Metadata["nfe.protocol"])
+	}
+
+	plainPath := filepath.Join(dir, "pedido.xml")
+	if err := os.WriteFile(plainPath, []byte("<pedido><cliente>Maria</cliente><valor>10</valor></pedido>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	document, err = (&NfeExtractor{}).ExtractText(plainPath)
+	if err != nil || document.Metadata["format"] != "xml" || document.Text != "Maria\n10" {
+		t.Errorf("plain XML = %+v, %v", document, err)
+	}
+}
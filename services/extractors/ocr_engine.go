@@ -0,0 +1,228 @@
+package extractors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"relatorios/models"
+	"relatorios/services/language"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const ocrWarningSource = "ocr"
+
+var errMissingLanguageData = errors.New("tesseract language data not found")
+
+var tesseractInfoPrefixes = []string{
+	"Tesseract Open Source OCR Engine",
+	"Estimating resolution as",
+	"Page ",
+}
+
+type OCREngine struct {
+	options       models.OCROptions
+	tesseractPath string
+	lookupErr     error
+	located       bool
+}
+
+type ocrResult struct {
+	text     string
+	language string
+	warnings []models.ExtractionWarning
+}
+
+func NewOCREngine(options models.OCROptions) *OCREngine {
+	return &OCREngine{options: options}
+}
+
+func (o *OCREngine) GetOptions() models.OCROptions {
+	return o.options
+}
+
+func (o *OCREngine) Tesseract() (string, error) {
+	if !o.located {
+		o.tesseractPath, o.lookupErr = findTesseract(runtime.GOOS)
+		o.located = true
+	}
+	return o.tesseractPath, o.lookupErr
+}
+
+func (o *OCREngine) Recognize(ctx context.Context, imagePath string, options models.OCROptions, dpi int) (ocrResult, error) {
+	tesseractPath, err := o.Tesseract()
+	if err != nil {
+		return ocrResult{}, err
+	}
+
+	tempDir, err := os.MkdirTemp("", "tesseract-output")
+	if err != nil {
+		return ocrResult{}, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	outputPrefix := filepath.Join(tempDir, "output")
+
+	languages := options.LanguageList()
+	detectLanguage := len(languages) == 0
+	if detectLanguage {
+		languages = []string{language.Portuguese}
+	}
+
+	text, warnings, err := o.run(ctx, tesseractPath, imagePath, outputPrefix, languages, options, dpi)
+	if errors.Is(err, errMissingLanguageData) && strings.Join(languages, "+") != language.English {
+		warnings = append(warnings, ocrWarning(0, fmt.Sprintf("language data for %s not found, using %s", strings.Join(languages, "+"), language.English)))
+		languages = []string{language.English}
+
+		var fallbackWarnings []models.ExtractionWarning
+		text, fallbackWarnings, err = o.run(ctx, tesseractPath, imagePath, outputPrefix, languages, options, dpi)
+		warnings = appendNewWarnings(warnings, fallbackWarnings)
+	}
+	if err != nil {
+		return ocrResult{}, err
+	}
+
+	detectedLanguage := language.Detect(text)
+
+	if detectLanguage && detectedLanguage != "" && detectedLanguage != languages[0] {
+		rerunText, rerunWarnings, err := o.run(ctx, tesseractPath, imagePath, outputPrefix, []string{detectedLanguage}, options, dpi)
+		if err == nil && strings.TrimSpace(rerunText) != "" {
+			text = rerunText
+			warnings = appendNewWarnings(warnings, rerunWarnings)
+		}
+	}
+
+	return ocrResult{text: text, language: detectedLanguage, warnings: warnings}, nil
+}
+
+func (o *OCREngine) run(ctx context.Context, tesseractPath, imagePath, outputPrefix string, languages []string, options models.OCROptions, dpi int) (string, []models.ExtractionWarning, error) {
+	args := []string{imagePath, outputPrefix, "-l", strings.Join(languages, "+")}
+	if options.TessdataDir != "" {
+		args = append(args, "--tessdata-dir", options.TessdataDir)
+	}
+	if options.PageSegmentationMode != nil {
+		args = append(args, "--psm", strconv.Itoa(*options.PageSegmentationMode))
+	}
+	if options.EngineMode != nil {
+		args = append(args, "--oem", strconv.Itoa(*options.EngineMode))
+	}
+	if dpi > 0 {
+		args = append(args, "--dpi", strconv.Itoa(dpi))
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, tesseractPath, args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		if timeoutErr := ocrTimeout(ctx, options); timeoutErr != nil {
+			return "", nil, timeoutErr
+		}
+		if strings.Contains(output.String(), "Error opening data file") ||
+			strings.Contains(output.String(), "Failed loading language") {
+			return "", nil, fmt.Errorf("%w: %s", errMissingLanguageData, strings.TrimSpace(output.String()))
+		}
+		return "", nil, fmt.Errorf("failed to execute OCR: %w\nOutput: %s", err, output.String())
+	}
+
+	textBytes, err := os.ReadFile(outputPrefix + ".txt")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read OCR output: %w", err)
+	}
+
+	return string(textBytes), tesseractWarnings(output.String()), nil
+}
+
+func ocrTimeout(ctx context.Context, options models.OCROptions) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("OCR timed out after %s", options.Timeout())
+	}
+	return nil
+}
+
+func tesseractWarnings(output string) []models.ExtractionWarning {
+	var warnings []models.ExtractionWarning
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || hasAnyPrefix(line, tesseractInfoPrefixes) {
+			continue
+		}
+		warnings = append(warnings, ocrWarning(0, line))
+	}
+
+	return warnings
+}
+
+func appendNewWarnings(warnings []models.ExtractionWarning, additional []models.ExtractionWarning) []models.ExtractionWarning {
+	for _, warning := range additional {
+		duplicate := false
+		for _, existing := range warnings {
+			if existing == warning {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func ocrWarning(page int, message string) models.ExtractionWarning {
+	return models.ExtractionWarning{Source: ocrWarningSource, Page: page, Message: message}
+}
+
+func findTesseract(osType string) (string, error) {
+	tesseractPath, err := exec.LookPath("tesseract")
+	if err == nil {
+		return tesseractPath, nil
+	}
+
+	var possiblePaths []string
+
+	switch osType {
+	case "windows":
+		possiblePaths = []string{
+			"C:\\Program Files\\Tesseract-OCR\\tesseract.exe",
+			"C:\\Program Files (x86)\\Tesseract-OCR\\tesseract.exe",
+			"C:\\Tesseract-OCR\\tesseract.exe",
+		}
+	case "darwin":
+		possiblePaths = []string{
+			"/usr/local/bin/tesseract",
+			"/opt/homebrew/bin/tesseract",
+			"/opt/local/bin/tesseract",
+		}
+	case "linux":
+		possiblePaths = []string{
+			"/usr/bin/tesseract",
+			"/usr/local/bin/tesseract",
+			"/snap/bin/tesseract",
+		}
+	}
+
+	for _, path := range possiblePaths {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("tesseract not found")
+}
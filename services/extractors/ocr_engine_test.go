@@ -0,0 +1,146 @@
+package extractors
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const fakeTesseractScript = `#!/bin/sh
+echo "$4" >> "$(dirname "$0")/calls.log"
+if [ -n "$FAKE_TESSERACT_SLEEP" ]; then exec sleep "$FAKE_TESSERACT_SLEEP"; fi
+echo "We received from the company the amount for maintenance services provided during April, according to the invoice." > "$2.txt"
+`
+
+func newFakeTesseract(t *testing.T) (*OCREngine, func() []string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake tesseract is a shell script")
+	}
+
+	dir := t.TempDir()
+	tesseractPath := filepath.Join(dir, "tesseract")
+	if err := os.WriteFile(tesseractPath, []byte(fakeTesseractScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := &OCREngine{tesseractPath: tesseractPath, located: true}
+	calls := func() []string {
+		data, _ := os.ReadFile(filepath.Join(dir, "calls.log"))
+		return strings.Fields(string(data))
+	}
+	return engine, calls
+}
+
+func TestRecognizeRerunsOnlyWithoutConfiguredLanguage(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages string
+		want      []string
+	}{
+		{"no language configured", "", []string{"por", "eng"}},
+		{"one language configured", "por", []string{"por"}},
+		{"several languages configured", "por+spa", []string{"por+spa"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			engine, calls := newFakeTesseract(t)
+
+			result, err := engine.Recognize(context.Background(), "scan.png", models.OCROptions{Languages: test.languages}, 0)
+			if err != nil {
+				t.Fatalf("Recognize: %v", err)
+			}
+			if result.language != "eng" {
+				t.Errorf("language = %q, want eng", result.language)
+			}
+			if got := calls(); strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("tesseract runs = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRecognizeSharesTheCallerDeadline(t *testing.T) {
+	engine, calls := newFakeTesseract(t)
+	t.Setenv("FAKE_TESSERACT_SLEEP", "5")
+
+	options := models.OCROptions{TimeoutSeconds: 1}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := engine.Recognize(ctx, "scan.png", options, 0)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Recognize took %s, want it bounded by the caller's deadline", elapsed)
+	}
+	if got := calls(); len(got) != 1 {
+		t.Errorf("tesseract runs = %q, want a single run", got)
+	}
+}
+
+func TestRenderPageStopsAtDeadline(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake rasterizer is a shell script")
+	}
+
+	dir := t.TempDir()
+	toolPath := filepath.Join(dir, "mutool")
+	if err := os.WriteFile(toolPath, []byte("#!/bin/sh\nexec sleep 5\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	rasterizer := &pdfRasterizer{toolName: "mutool", toolPath: toolPath}
+	if _, err := rasterizer.RenderPage(ctx, "scan.pdf", 1, dir, 150); err == nil {
+		t.Fatal("RenderPage succeeded, want an error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("RenderPage took %s, want it stopped at the deadline", elapsed)
+	}
+	if err := ocrTimeout(ctx, models.OCROptions{TimeoutSeconds: 1}); err == nil || err.Error() != "OCR timed out after 1s" {
+		t.Errorf("ocrTimeout = %v", err)
+	}
+}
+
+func TestExtractDocumentPassesOCROptionsToTheExtractor(t *testing.T) {
+	engine, calls := newFakeTesseract(t)
+	factory := NewDocumentExtractorFactory()
+	*factory.ocr = *engine
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, image.NewGray(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+	imagePath := filepath.Join(t.TempDir(), "recibo.png")
+	if err := os.WriteFile(imagePath, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, languages := range []string{"spa", "por+eng"} {
+		if _, err := factory.ExtractDocument(imagePath, DetectContentFormat(imagePath), models.OCROptions{Languages: languages}); err != nil {
+			t.Fatalf("ExtractDocument: %v", err)
+		}
+	}
+
+	if got := strings.Join(calls(), ","); got != "spa,por+eng" {
+		t.Errorf("tesseract languages = %q, want each call's options", got)
+	}
+	if options := factory.ocr.GetOptions(); options != (models.OCROptions{}) {
+		t.Errorf("engine options = %+v, want them left untouched", options)
+	}
+}
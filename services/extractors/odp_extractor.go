@@ -0,0 +1,86 @@
+package extractors
+
+import (
+	"fmt"
+	"path/filepath"
+	"relatorios/models"
+	"strconv"
+	"strings"
+)
+
+type OdpExtractor struct{}
+
+func (e *OdpExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	parts, err := readOpenDocumentParts(filePath, "content.xml")
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to open OpenDocument presentation: %w", err)
+	}
+
+	collector := &odfTextCollector{sections: newSectionTexts()}
+
+	var slides []slideText
+	for _, presentation := range parts["content.xml"].find("presentation") {
+		for _, page := range presentation.children {
+			if page.name != "page" {
+				continue
+			}
+
+			slide := slideText{text: strings.Join(collector.blocks(page), "\n")}
+			for _, notes := range page.find("notes") {
+				slide.notes = append(slide.notes, collector.blocks(notes)...)
+			}
+			slides = append(slides, slide)
+		}
+	}
+
+	document := buildPresentationDocument(filePath, slides, "odp")
+	readPackageProperties(filePath, document.Info)
+
+	return document, nil
+}
+
+func (e *OdpExtractor) IsSupportedFormat(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".odp"
+}
+
+func (e *OdpExtractor) GetSupportedFormats() []string {
+	return []string{".odp"}
+}
+
+type slideText struct {
+	text  string
+	notes []string
+}
+
+func buildPresentationDocument(filePath string, slides []slideText, format string) models.DocumentMetadata {
+	var textContent strings.Builder
+	sections := newSectionTexts()
+	pages := make([]models.DocumentPage, 0, len(slides))
+
+	for i, slide := range slides {
+		textContent.WriteString(fmt.Sprintf("\n[Slide: %d]\n", i+1))
+		textContent.WriteString(strings.TrimSpace(slide.text) + "\n")
+		sections.add(sectionBody, slide.text)
+
+		notes := strings.TrimSpace(strings.Join(slide.notes, "\n"))
+		if notes != "" {
+			textContent.WriteString(fmt.Sprintf("[Notes: %d]\n", i+1))
+			textContent.WriteString(notes + "\n")
+			sections.add(sectionNotes, notes)
+		}
+
+		pages = append(pages, models.NewDocumentPage(i+1, strings.TrimSpace(slide.text+"\n\n"+notes)))
+	}
+
+	return models.DocumentMetadata{
+		Filename: filepath.Base(filePath),
+		Text:     textContent.String(),
+		Sections: sections.result(),
+		Pages:    pages,
+		Metadata: map[string]string{
+			"format":     format,
+			"slideCount": strconv.Itoa(len(slides)),
+		},
+		Info: &models.DocumentInfo{SlideCount: len(slides)},
+	}
+}
@@ -0,0 +1,44 @@
+package extractors
+
+import (
+	"fmt"
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+)
+
+type OdsExtractor struct{}
+
+func (e *OdsExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	parts, err := readOpenDocumentParts(filePath, "content.xml")
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to open OpenDocument spreadsheet: %w", err)
+	}
+
+	collector := &odfTextCollector{sections: newSectionTexts()}
+
+	var sheets []sheetData
+	for _, spreadsheet := range parts["content.xml"].find("spreadsheet") {
+		for _, table := range spreadsheet.children {
+			if table.name != "table" {
+				continue
+			}
+
+			rows := collector.rows(table)
+			sheets = append(sheets, sheetData{name: table.attr("name"), rows: rows, cellCount: countCells(rows)})
+		}
+	}
+
+	document := buildSpreadsheetDocument(filePath, sheets, "ods")
+	readPackageProperties(filePath, document.Info)
+
+	return document, nil
+}
+
+func (e *OdsExtractor) IsSupportedFormat(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".ods"
+}
+
+func (e *OdsExtractor) GetSupportedFormats() []string {
+	return []string{".ods"}
+}
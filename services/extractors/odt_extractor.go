@@ -0,0 +1,62 @@
+package extractors
+
+import (
+	"fmt"
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+)
+
+type OdtExtractor struct{}
+
+func (e *OdtExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	parts, err := readOpenDocumentParts(filePath, "content.xml", "styles.xml")
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to open OpenDocument text: %w", err)
+	}
+
+	sections := newSectionTexts()
+	collector := &odfTextCollector{sections: sections}
+	for _, root := range parts {
+		collector.collectPageBreakStyles(root)
+	}
+
+	for _, body := range parts["content.xml"].find("text") {
+		sections.add(sectionBody, strings.Join(collector.blocks(body), "\n"))
+	}
+
+	if styles, ok := parts["styles.xml"]; ok {
+		for _, name := range []string{"header", "header-left", "header-first"} {
+			for _, header := range styles.find(name) {
+				sections.add(sectionHeader, strings.Join(collector.blocks(header), "\n"))
+			}
+		}
+		for _, name := range []string{"footer", "footer-left", "footer-first"} {
+			for _, footer := range styles.find(name) {
+				sections.add(sectionFooter, strings.Join(collector.blocks(footer), "\n"))
+			}
+		}
+	}
+
+	result := sections.result()
+	pages := splitBodyPages(result)
+
+	info := &models.DocumentInfo{}
+	readPackageProperties(filePath, info)
+
+	return models.DocumentMetadata{
+		Filename: filepath.Base(filePath),
+		Text:     formatDocumentSections(result),
+		Sections: result,
+		Pages:    pages,
+		Info:     info,
+	}, nil
+}
+
+func (e *OdtExtractor) IsSupportedFormat(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".odt"
+}
+
+func (e *OdtExtractor) GetSupportedFormats() []string {
+	return []string{".odt"}
+}
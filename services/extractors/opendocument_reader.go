@@ -0,0 +1,265 @@
+package extractors
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxRepeatedCells = 1024
+	maxRepeatedRows  = 1000
+	maxZipPartSize   = 100 << 20
+)
+
+func readOpenDocumentParts(filePath string, names ...string) (map[string]*xmlNode, error) {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	files := make(map[string]*zip.File)
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+
+	if manifest, ok := files["META-INF/manifest.xml"]; ok {
+		data, err := readZipFile(manifest)
+		if err == nil && bytes.Contains(data, []byte("encryption-data")) {
+			return nil, fmt.Errorf("password-protected documents are not supported")
+		}
+	}
+
+	parts := make(map[string]*xmlNode)
+	for _, name := range names {
+		file, ok := files[name]
+		if !ok {
+			continue
+		}
+
+		data, err := readZipFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		root, err := parseXMLTree(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		parts[name] = root
+	}
+
+	if parts["content.xml"] == nil {
+		return nil, fmt.Errorf("content.xml not found")
+	}
+
+	return parts, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxZipPartSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxZipPartSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", file.Name, maxZipPartSize)
+	}
+
+	return data, nil
+}
+
+type odfTextCollector struct {
+	sections    *sectionTexts
+	breakBefore map[string]bool
+	breakAfter  map[string]bool
+}
+
+func (c *odfTextCollector) collectPageBreakStyles(root *xmlNode) {
+	if c.breakBefore == nil {
+		c.breakBefore = make(map[string]bool)
+		c.breakAfter = make(map[string]bool)
+	}
+
+	for _, style := range root.find("style") {
+		for _, properties := range style.find("paragraph-properties") {
+			if properties.attr("break-before") == "page" {
+				c.breakBefore[style.attr("name")] = true
+			}
+			if properties.attr("break-after") == "page" {
+				c.breakAfter[style.attr("name")] = true
+			}
+		}
+	}
+}
+
+func (c *odfTextCollector) blocks(node *xmlNode) []string {
+	var lines []string
+	pendingBreak := false
+
+	for _, child := range node.children {
+		var block []string
+
+		switch child.name {
+		case "p", "h":
+			line := c.inline(child)
+			if c.breakBefore[child.attr("style-name")] {
+				line = pageBreak + line
+			}
+			if c.breakAfter[child.attr("style-name")] {
+				line += pageBreak
+			}
+			block = []string{line}
+		case "table":
+			block = c.tableRows(child)
+		case "soft-page-break":
+			pendingBreak = true
+			continue
+		case "notes", "annotation", "tracked-changes", "sequence-decls", "forms", "title", "desc":
+			continue
+		default:
+			block = c.blocks(child)
+		}
+
+		if pendingBreak && len(block) > 0 {
+			block[0] = pageBreak + block[0]
+			pendingBreak = false
+		}
+		lines = append(lines, block...)
+	}
+
+	return lines
+}
+
+func (c *odfTextCollector) inline(node *xmlNode) string {
+	var builder strings.Builder
+
+	for _, child := range node.children {
+		switch child.name {
+		case "":
+			builder.WriteString(child.text)
+		case "s":
+			count, err := strconv.Atoi(child.attr("c"))
+			if err != nil || count < 1 {
+				count = 1
+			}
+			builder.WriteString(strings.Repeat(" ", count))
+		case "tab":
+			builder.WriteString("\t")
+		case "line-break":
+			builder.WriteString("\n")
+		case "soft-page-break":
+			builder.WriteString(pageBreak)
+		case "note":
+			c.collectNote(child)
+		case "annotation":
+			c.sections.add(sectionComments, strings.Join(c.blocks(child), "\n"))
+		case "text-box":
+			c.sections.add(sectionTextBox, strings.Join(c.blocks(child), "\n"))
+		case "annotation-end", "bookmark", "bookmark-start", "bookmark-end", "title", "desc":
+			continue
+		default:
+			builder.WriteString(c.inline(child))
+		}
+	}
+
+	return builder.String()
+}
+
+func (c *odfTextCollector) collectNote(node *xmlNode) {
+	section := sectionFootnotes
+	if node.attr("note-class") == "endnote" {
+		section = sectionEndnotes
+	}
+
+	for _, body := range node.find("note-body") {
+		c.sections.add(section, strings.Join(c.blocks(body), "\n"))
+	}
+}
+
+func (c *odfTextCollector) tableRows(table *xmlNode) []string {
+	var lines []string
+	for _, row := range c.rows(table) {
+		if len(row) > 0 {
+			lines = append(lines, strings.Join(row, " | "))
+		}
+	}
+	return lines
+}
+
+func (c *odfTextCollector) rows(table *xmlNode) [][]string {
+	var rows [][]string
+
+	for _, child := range table.children {
+		switch child.name {
+		case "table-row":
+			row := c.row(child)
+			if len(row) == 0 {
+				continue
+			}
+
+			repeat := repeatCount(child.attr("number-rows-repeated"), maxRepeatedRows)
+			for i := 0; i < repeat; i++ {
+				rows = append(rows, row)
+			}
+		case "table-header-rows", "table-rows", "table-row-group":
+			rows = append(rows, c.rows(child)...)
+		}
+	}
+
+	return rows
+}
+
+func (c *odfTextCollector) row(node *xmlNode) []string {
+	var cells []string
+	pendingEmpty := 0
+
+	for _, cell := range node.children {
+		if cell.name != "table-cell" && cell.name != "covered-table-cell" {
+			continue
+		}
+
+		repeat := repeatCount(cell.attr("number-columns-repeated"), maxRepeatedCells)
+
+		value := strings.Join(strings.Fields(strings.Join(c.blocks(cell), " ")), " ")
+		if value == "" {
+			value = cell.attr("value")
+		}
+
+		if value == "" {
+			pendingEmpty += repeat
+			continue
+		}
+
+		for ; pendingEmpty > 0 && len(cells) < maxRepeatedCells; pendingEmpty-- {
+			cells = append(cells, "")
+		}
+		pendingEmpty = 0
+
+		for i := 0; i < repeat && len(cells) < maxRepeatedCells; i++ {
+			cells = append(cells, value)
+		}
+	}
+
+	return cells
+}
+
+func repeatCount(value string, limit int) int {
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 1 {
+		return 1
+	}
+	if count > limit {
+		return limit
+	}
+	return count
+}
@@ -1,23 +1,42 @@
 package extractors
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"relatorios/models"
+	"strconv"
+	"strings"
 
 	"github.com/ledongthuc/pdf"
 )
 
-type PdfExtractor struct{}
+const minPageTextLength = 20
+
+type PdfExtractor struct {
+	ocr *OCREngine
+}
 
 func (e *PdfExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	return e.ExtractTextWithOCR(filePath, e.ocr.GetOptions())
+}
+
+func (e *PdfExtractor) ExtractTextWithOCR(filePath string, options models.OCROptions) (models.DocumentMetadata, error) {
 	f, r, err := pdf.Open(filePath)
 	if err != nil {
 		return models.DocumentMetadata{}, fmt.Errorf("failed to open PDF: %w", err)
 	}
 	defer f.Close()
 
-	var text string
+	var pages []models.DocumentPage
+	var ocrPages []string
+	var warnings []models.ExtractionWarning
+	reportedErrors := make(map[string]bool)
+	metadata := make(map[string]string)
+	ocr := &pdfPageOCR{engine: e.ocr, options: options}
+	defer ocr.Close()
+
 	totalPage := r.NumPage()
 
 	for pageIndex := 1; pageIndex <= totalPage; pageIndex++ {
@@ -27,23 +46,145 @@ func (e *PdfExtractor) ExtractText(filePath string) (models.DocumentMetadata, er
 		}
 
 		pageText, err := p.GetPlainText(nil)
-		if err != nil {
-			return models.DocumentMetadata{}, fmt.Errorf("failed to extract text from page %d: %w", pageIndex, err)
+		if err == nil && len(strings.TrimSpace(pageText)) >= minPageTextLength {
+			pages = append(pages, models.NewDocumentPage(pageIndex, pageText))
+			continue
+		}
+
+		ocrText, ocrWarnings, ocrErr := ocr.RecognizePage(filePath, pageIndex)
+		warnings = append(warnings, ocrWarnings...)
+		if ocrErr != nil {
+			if err != nil {
+				return models.DocumentMetadata{}, fmt.Errorf("failed to extract text from page %d: %w", pageIndex, err)
+			}
+			if !reportedErrors[ocrErr.Error()] {
+				reportedErrors[ocrErr.Error()] = true
+				warnings = append(warnings, ocrWarning(pageIndex, ocrErr.Error()))
+			}
+			pages = append(pages, models.NewDocumentPage(pageIndex, pageText))
+			continue
 		}
-		text += pageText
+
+		if ocrLength := len(strings.TrimSpace(ocrText)); ocrLength == 0 || ocrLength < len(strings.TrimSpace(pageText)) {
+			pages = append(pages, models.NewDocumentPage(pageIndex, pageText))
+			continue
+		}
+
+		ocrPages = append(ocrPages, strconv.Itoa(pageIndex))
+		pages = append(pages, models.NewDocumentPage(pageIndex, ocrText))
+	}
+
+	if len(ocrPages) > 0 {
+		metadata["ocrPages"] = strings.Join(ocrPages, ",")
 	}
 
-	return models.DocumentMetadata{
+	document := models.DocumentMetadata{
 		Filename: filepath.Base(filePath),
-		Text:     text,
-	}, nil
+		Info:     e.info(r, totalPage),
+		Warnings: warnings,
+	}
+	document.SetPages(pages)
+	if len(metadata) > 0 {
+		document.Metadata = metadata
+	}
+
+	return document, nil
+}
+
+func (e *PdfExtractor) info(r *pdf.Reader, pageCount int) *models.DocumentInfo {
+	properties := r.Trailer().Key("Info")
+
+	return &models.DocumentInfo{
+		PageCount: pageCount,
+		Title:     strings.TrimSpace(properties.Key("Title").Text()),
+		Author:    strings.TrimSpace(properties.Key("Author").Text()),
+		Created:   parsePDFDate(properties.Key("CreationDate").Text()),
+		Modified:  parsePDFDate(properties.Key("ModDate").Text()),
+	}
 }
 
 func (e *PdfExtractor) IsSupportedFormat(filePath string) bool {
-	ext := filepath.Ext(filePath)
+	ext := strings.ToLower(filepath.Ext(filePath))
 	return ext == ".pdf"
 }
 
 func (e *PdfExtractor) GetSupportedFormats() []string {
 	return []string{".pdf"}
 }
+
+type pdfPageOCR struct {
+	engine      *OCREngine
+	options     models.OCROptions
+	rasterizer  *pdfRasterizer
+	tempDir     string
+	unavailable error
+}
+
+func (o *pdfPageOCR) RecognizePage(pdfPath string, pageNumber int) (string, []models.ExtractionWarning, error) {
+	if err := o.prepare(); err != nil {
+		return "", nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.options.Timeout())
+	defer cancel()
+
+	imagePath, err := o.rasterizer.RenderPage(ctx, pdfPath, pageNumber, o.tempDir, o.options.RasterDPI())
+	if err != nil {
+		if timeoutErr := ocrTimeout(ctx, o.options); timeoutErr != nil {
+			return "", nil, timeoutErr
+		}
+		return "", nil, err
+	}
+	defer os.Remove(imagePath)
+
+	result, err := o.engine.Recognize(ctx, imagePath, o.options, o.options.RasterDPI())
+	if err != nil {
+		return "", nil, err
+	}
+
+	var warnings []models.ExtractionWarning
+	for _, warning := range result.warnings {
+		warning.Page = pageNumber
+		warnings = append(warnings, warning)
+	}
+
+	return strings.TrimSpace(result.text), warnings, nil
+}
+
+func (o *pdfPageOCR) prepare() error {
+	if o.unavailable != nil {
+		return o.unavailable
+	}
+
+	if o.tempDir != "" {
+		return nil
+	}
+
+	if _, err := o.engine.Tesseract(); err != nil {
+		o.unavailable = fmt.Errorf("OCR not available for scanned pages: %w", err)
+		return o.unavailable
+	}
+
+	rasterizer, err := findPdfRasterizer()
+	if err != nil {
+		o.unavailable = fmt.Errorf("OCR not available for scanned pages: %w", err)
+		return o.unavailable
+	}
+
+	tempDir, err := os.MkdirTemp("", "pdf-ocr")
+	if err != nil {
+		o.unavailable = fmt.Errorf("failed to create temporary directory: %w", err)
+		return o.unavailable
+	}
+
+	o.rasterizer = rasterizer
+	o.tempDir = tempDir
+
+	return nil
+}
+
+func (o *pdfPageOCR) Close() {
+	if o.tempDir != "" {
+		os.RemoveAll(o.tempDir)
+	}
+}
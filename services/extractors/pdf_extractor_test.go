@@ -0,0 +1,111 @@
+package extractors
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeTestPDF(t *testing.T, path string, pageTexts ...string) {
+	t.Helper()
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+
+	var kids []string
+	for _, text := range pageTexts {
+		content := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+		if text == "" {
+			content = ""
+		}
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+		contentID := len(objects)
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", contentID))
+		kids = append(kids, fmt.Sprintf("%d 0 R", len(objects)))
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+
+	var buffer bytes.Buffer
+	buffer.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buffer.Len()
+		fmt.Fprintf(&buffer, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buffer.Len()
+	fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPdfExtractorFallsBackToOCR(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub rasterizer and tesseract are shell scripts")
+	}
+
+	toolDir := t.TempDir()
+	rasterizer := "#!/bin/sh\nfor last; do :; done\n: > \"$last.png\"\n"
+	if err := os.WriteFile(filepath.Join(toolDir, "pdftoppm"), []byte(rasterizer), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tesseract := "#!/bin/sh\nprintf '%s' \"$FAKE_OCR_TEXT\" > \"$2.txt\"\n"
+	tesseractPath := filepath.Join(toolDir, "tesseract")
+	if err := os.WriteFile(tesseractPath, []byte(tesseract), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", toolDir)
+
+	path := filepath.Join(t.TempDir(), "digitalizado.pdf")
+	writeTestPDF(t, path, "Nota fiscal eletronica de servicos prestados", "Folha 2", "")
+
+	tests := []struct {
+		name         string
+		ocrText      string
+		wantPages    []string
+		wantOCRPages string
+	}{
+		{"OCR replaces short text", "Pagina digitalizada com o valor total", []string{"Nota fiscal eletronica de servicos prestados", "Pagina digitalizada com o valor total", "Pagina digitalizada com o valor total"}, "2,3"},
+		{"blank OCR keeps native text", "  \n ", []string{"Nota fiscal eletronica de servicos prestados", "Folha 2", ""}, ""},
+		{"shorter OCR keeps native text", "Fl 2", []string{"Nota fiscal eletronica de servicos prestados", "Folha 2", "Fl 2"}, "3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("FAKE_OCR_TEXT", test.ocrText)
+
+			engine := NewOCREngine(models.DefaultOCROptions())
+			engine.tesseractPath, engine.located = tesseractPath, true
+
+			document, err := (&PdfExtractor{ocr: engine}).ExtractText(path)
+			if err != nil {
+				t.Fatalf("ExtractText: %v", err)
+			}
+
+			var pages []string
+			for _, page := range document.Pages {
+				pages = append(pages, page.Text())
+			}
+			if strings.Join(pages, "|") != strings.Join(test.wantPages, "|") {
+				t.Errorf("pages = %q, want %q", pages, test.wantPages)
+			}
+			if document.Metadata["ocrPages"] != test.wantOCRPages {
+				t.Errorf("ocrPages = %q, want %q", document.Metadata["ocrPages"], test.wantOCRPages)
+			}
+		})
+	}
+}
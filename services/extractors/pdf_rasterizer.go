@@ -0,0 +1,58 @@
+package extractors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+type pdfRasterizer struct {
+	toolName string
+	toolPath string
+}
+
+func findPdfRasterizer() (*pdfRasterizer, error) {
+	for _, toolName := range []string{"pdftoppm", "gs", "gswin64c", "mutool"} {
+		if toolPath, err := exec.LookPath(toolName); err == nil {
+			return &pdfRasterizer{toolName: toolName, toolPath: toolPath}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no PDF rasterizer found (install poppler-utils, ghostscript or mupdf-tools)")
+}
+
+func (r *pdfRasterizer) RenderPage(ctx context.Context, pdfPath string, pageNumber int, outputDir string, dpi int) (string, error) {
+	page := strconv.Itoa(pageNumber)
+	imagePath := filepath.Join(outputDir, fmt.Sprintf("page-%d.png", pageNumber))
+
+	var cmd *exec.Cmd
+	switch r.toolName {
+	case "pdftoppm":
+		outputPrefix := filepath.Join(outputDir, fmt.Sprintf("page-%d", pageNumber))
+		cmd = exec.CommandContext(ctx, r.toolPath, "-f", page, "-l", page, "-r", strconv.Itoa(dpi), "-png", "-singlefile", pdfPath, outputPrefix)
+	case "mutool":
+		cmd = exec.CommandContext(ctx, r.toolPath, "draw", "-r", strconv.Itoa(dpi), "-o", imagePath, pdfPath, page)
+	default:
+		cmd = exec.CommandContext(ctx, r.toolPath,
+			"-dSAFER", "-dBATCH", "-dNOPAUSE", "-dQUIET",
+			"-sDEVICE=png16m",
+			"-r"+strconv.Itoa(dpi),
+			"-dFirstPage="+page,
+			"-dLastPage="+page,
+			"-sOutputFile="+imagePath,
+			pdfPath)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to render page %d with %s: %w\nOutput: %s", pageNumber, r.toolName, err, string(output))
+	}
+
+	if _, err := os.Stat(imagePath); err != nil {
+		return "", fmt.Errorf("rendered image for page %d not found: %w", pageNumber, err)
+	}
+
+	return imagePath, nil
+}
@@ -0,0 +1,198 @@
+package extractors
+
+import (
+	"archive/zip"
+	"fmt"
+	"path"
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+)
+
+const (
+	relationshipsNamespace = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	notesSlideRelationship = "/notesSlide"
+)
+
+type PptxExtractor struct{}
+
+func (e *PptxExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to open PowerPoint file: %w", err)
+	}
+	defer reader.Close()
+
+	files := make(map[string]*zip.File)
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+
+	readPart := func(name string) (*xmlNode, error) {
+		file, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+
+		data, err := readZipFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		return parseXMLTree(data)
+	}
+
+	presentation, err := readPart("ppt/presentation.xml")
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to read PowerPoint presentation: %w", err)
+	}
+
+	relationships, err := readPart("ppt/_rels/presentation.xml.rels")
+	if err != nil {
+		return models.DocumentMetadata{}, fmt.Errorf("failed to read PowerPoint presentation: %w", err)
+	}
+	targets := resolveRelationships("ppt/presentation.xml", relationships)
+
+	var slides []slideText
+	for _, slideID := range presentation.find("sldId") {
+		slidePath, ok := targets[slideID.attrNS(relationshipsNamespace, "id")]
+		if !ok {
+			continue
+		}
+
+		slidePart, err := readPart(slidePath)
+		if err != nil {
+			return models.DocumentMetadata{}, fmt.Errorf("failed to read slide %d: %w", len(slides)+1, err)
+		}
+
+		slide := slideText{text: strings.Join(pptxShapeText(slidePart, false), "\n")}
+
+		if notesPath := e.notesPath(slidePath, readPart); notesPath != "" {
+			if notesPart, err := readPart(notesPath); err == nil {
+				slide.notes = pptxShapeText(notesPart, true)
+			}
+		}
+
+		slides = append(slides, slide)
+	}
+
+	document := buildPresentationDocument(filePath, slides, "pptx")
+	readPackageProperties(filePath, document.Info)
+
+	return document, nil
+}
+
+func (e *PptxExtractor) notesPath(slidePath string, readPart func(string) (*xmlNode, error)) string {
+	relationshipsPath := path.Join(path.Dir(slidePath), "_rels", path.Base(slidePath)+".rels")
+
+	relationships, err := readPart(relationshipsPath)
+	if err != nil {
+		return ""
+	}
+
+	for _, relationship := range relationships.find("Relationship") {
+		if strings.HasSuffix(relationship.attr("Type"), notesSlideRelationship) {
+			return resolvePartPath(slidePath, relationship.attr("Target"))
+		}
+	}
+
+	return ""
+}
+
+func (e *PptxExtractor) IsSupportedFormat(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".pptx"
+}
+
+func (e *PptxExtractor) GetSupportedFormats() []string {
+	return []string{".pptx"}
+}
+
+func resolveRelationships(partPath string, relationships *xmlNode) map[string]string {
+	targets := make(map[string]string)
+	for _, relationship := range relationships.find("Relationship") {
+		if relationship.attr("TargetMode") == "External" {
+			continue
+		}
+		targets[relationship.attr("Id")] = resolvePartPath(partPath, relationship.attr("Target"))
+	}
+	return targets
+}
+
+func resolvePartPath(partPath, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(partPath), target)
+}
+
+func pptxShapeText(part *xmlNode, notesOnly bool) []string {
+	var lines []string
+
+	for _, tree := range part.find("spTree") {
+		lines = append(lines, pptxBlocks(tree, notesOnly)...)
+	}
+
+	return lines
+}
+
+func pptxBlocks(node *xmlNode, notesOnly bool) []string {
+	var lines []string
+
+	for _, child := range node.children {
+		switch child.name {
+		case "sp":
+			placeholder := pptxPlaceholderType(child)
+			if placeholder == "sldNum" || (notesOnly && placeholder != "body") {
+				continue
+			}
+			lines = append(lines, pptxBlocks(child, notesOnly)...)
+		case "p":
+			lines = append(lines, pptxParagraph(child))
+		case "tbl":
+			for _, row := range child.find("tr") {
+				var cells []string
+				for _, cell := range row.find("tc") {
+					cells = append(cells, strings.Join(strings.Fields(strings.Join(pptxBlocks(cell, false), " ")), " "))
+				}
+				lines = append(lines, strings.Join(cells, " | "))
+			}
+		case "nvSpPr", "nvGrpSpPr", "nvGraphicFramePr", "nvPicPr", "spPr", "grpSpPr":
+			continue
+		default:
+			lines = append(lines, pptxBlocks(child, notesOnly)...)
+		}
+	}
+
+	return lines
+}
+
+func pptxPlaceholderType(shape *xmlNode) string {
+	for _, properties := range shape.find("nvSpPr") {
+		for _, placeholder := range properties.find("ph") {
+			if placeholderType := placeholder.attr("type"); placeholderType != "" {
+				return placeholderType
+			}
+			return "body"
+		}
+	}
+	return ""
+}
+
+func pptxParagraph(node *xmlNode) string {
+	var builder strings.Builder
+
+	for _, child := range node.children {
+		switch child.name {
+		case "r", "fld":
+			for _, text := range child.find("t") {
+				for _, data := range text.children {
+					builder.WriteString(data.text)
+				}
+			}
+		case "br":
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String()
+}
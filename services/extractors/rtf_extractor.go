@@ -0,0 +1,260 @@
+package extractors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+var rtfSkippedDestinations = map[string]bool{
+	"fonttbl": true, "colortbl": true, "stylesheet": true, "listtable": true,
+	"listoverridetable": true, "revtbl": true, "rsidtbl": true, "generator": true,
+	"pict": true, "object": true, "themedata": true, "colorschememapping": true,
+	"datastore": true, "latentstyles": true, "xmlnstbl": true, "filetbl": true,
+	"info": true, "fldinst": true, "bkmkstart": true, "bkmkend": true,
+}
+
+var rtfInfoFields = map[string]string{
+	"title":    "title",
+	"subject":  "subject",
+	"author":   "author",
+	"operator": "lastAuthor",
+	"company":  "company",
+}
+
+var rtfCodePages = map[int]encoding.Encoding{
+	437:  charmap.CodePage437,
+	850:  charmap.CodePage850,
+	1250: charmap.Windows1250,
+	1251: charmap.Windows1251,
+	1252: charmap.Windows1252,
+}
+
+type rtfGroup struct {
+	skip        bool
+	infoField   string
+	unicodeSkip int
+}
+
+type RtfExtractor struct{}
+
+func (e *RtfExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return models.DocumentMetadata{}, err
+	}
+
+	if !strings.HasPrefix(string(data), "{\\rtf") {
+		return models.DocumentMetadata{}, fmt.Errorf("not an RTF document")
+	}
+
+	text, metadata := e.parse(data)
+	metadata["format"] = "rtf"
+
+	return models.DocumentMetadata{
+		Filename: filepath.Base(filePath),
+		Text:     text,
+		Metadata: metadata,
+	}, nil
+}
+
+func (e *RtfExtractor) parse(data []byte) (string, map[string]string) {
+	var text strings.Builder
+	metadata := make(map[string]string)
+	infoText := make(map[string]*strings.Builder)
+
+	codePage := encoding.Encoding(charmap.Windows1252)
+	stack := []rtfGroup{{unicodeSkip: 1}}
+	pendingSkip := 0
+	var pendingBytes []byte
+
+	write := func(s string) {
+		group := stack[len(stack)-1]
+		switch {
+		case group.infoField != "":
+			if infoText[group.infoField] == nil {
+				infoText[group.infoField] = &strings.Builder{}
+			}
+			infoText[group.infoField].WriteString(s)
+		case !group.skip:
+			text.WriteString(s)
+		}
+	}
+
+	flushBytes := func() {
+		if len(pendingBytes) == 0 {
+			return
+		}
+		decoded, err := codePage.NewDecoder().Bytes(pendingBytes)
+		if err != nil {
+			decoded = pendingBytes
+		}
+		write(string(decoded))
+		pendingBytes = pendingBytes[:0]
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if c != '\\' || i+1 >= len(data) || data[i+1] != '\'' {
+			flushBytes()
+		}
+
+		switch c {
+		case '{':
+			stack = append(stack, stack[len(stack)-1])
+			pendingSkip = 0
+		case '}':
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			pendingSkip = 0
+		case '\r', '\n':
+		case '\\':
+			i++
+			if i >= len(data) {
+				break
+			}
+
+			next := data[i]
+			switch {
+			case next == '\'':
+				if i+2 < len(data) {
+					if value, err := strconv.ParseUint(string(data[i+1:i+3]), 16, 8); err == nil {
+						if pendingSkip > 0 {
+							pendingSkip--
+						} else {
+							pendingBytes = append(pendingBytes, byte(value))
+						}
+					}
+					i += 2
+				}
+			case next == '*':
+				stack[len(stack)-1].skip = true
+			case next == '~':
+				write(" ")
+			case next == '_':
+				write("-")
+			case next == '-':
+			case next == '\n' || next == '\r':
+				write("\n")
+			case next == '{' || next == '}' || next == '\\':
+				if pendingSkip > 0 {
+					pendingSkip--
+				} else {
+					write(string(next))
+				}
+			case isASCIILetter(next):
+				start := i
+				for i < len(data) && isASCIILetter(data[i]) {
+					i++
+				}
+				word := string(data[start:i])
+
+				paramStart := i
+				if i < len(data) && data[i] == '-' {
+					i++
+				}
+				for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+					i++
+				}
+				param, hasParam := 0, i > paramStart
+				if hasParam {
+					param, _ = strconv.Atoi(string(data[paramStart:i]))
+				}
+
+				if i >= len(data) || data[i] != ' ' {
+					i--
+				}
+
+				pendingSkip = e.controlWord(word, param, hasParam, stack, &codePage, write, pendingSkip)
+			}
+		default:
+			if pendingSkip > 0 {
+				pendingSkip--
+				continue
+			}
+			write(string(rune(c)))
+		}
+	}
+	flushBytes()
+
+	for field, builder := range infoText {
+		if value := strings.TrimSpace(builder.String()); value != "" {
+			metadata[field] = value
+		}
+	}
+
+	return cleanRtfText(text.String()), metadata
+}
+
+func (e *RtfExtractor) controlWord(word string, param int, hasParam bool, stack []rtfGroup, codePage *encoding.Encoding, write func(string), pendingSkip int) int {
+	group := &stack[len(stack)-1]
+
+	switch {
+	case rtfSkippedDestinations[word]:
+		group.skip = true
+	case rtfInfoFields[word] != "" && len(stack) > 2 && stack[len(stack)-2].skip:
+		group.skip = false
+		group.infoField = rtfInfoFields[word]
+	case word == "par" || word == "line" || word == "sect" || word == "page":
+		write("\n")
+	case word == "tab":
+		write("\t")
+	case word == "cell":
+		write(" | ")
+	case word == "row":
+		write("\n")
+	case word == "emdash":
+		write("—")
+	case word == "endash":
+		write("–")
+	case word == "bullet":
+		write("•")
+	case word == "lquote" || word == "rquote":
+		write("'")
+	case word == "ldblquote" || word == "rdblquote":
+		write("\"")
+	case word == "ansicpg" && hasParam:
+		if pageEncoding, ok := rtfCodePages[param]; ok {
+			*codePage = pageEncoding
+		}
+	case word == "uc" && hasParam:
+		group.unicodeSkip = param
+	case word == "u" && hasParam:
+		if param < 0 {
+			param += 65536
+		}
+		write(string(rune(param)))
+		return group.unicodeSkip
+	}
+
+	return pendingSkip
+}
+
+func cleanRtfText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(strings.TrimSuffix(strings.TrimRightFunc(line, unicode.IsSpace), "|"), unicode.IsSpace)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (e *RtfExtractor) IsSupportedFormat(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".rtf"
+}
+
+func (e *RtfExtractor) GetSupportedFormats() []string {
+	return []string{".rtf"}
+}
@@ -0,0 +1,64 @@
+package extractors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRtfExtractorParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		rtf      string
+		want     string
+		metadata map[string]string
+	}{
+		{
+			name:     "windows-1252 escapes and skipped destinations",
+			rtf:      `{\rtf1\ansi\ansicpg1252{\fonttbl{\f0 Arial;}}{\colortbl;\red0\green0\blue0;}{\*\generator Riched20;}\f0 Presta\'e7\'e3o de servi\'e7os\par Valor:\tab 10\par}`,
+			want:     "Prestação de serviços\nValor:\t10",
+			metadata: map[string]string{},
+		},
+		{
+			name:     "info fields",
+			rtf:      `{\rtf1\ansi{\info{\title Relat\'f3rio anual}{\author Maria}{\operator Jo\'e3o}{\creatim\yr2024}}Texto}`,
+			want:     "Texto",
+			metadata: map[string]string{"title": "Relatório anual", "author": "Maria", "lastAuthor": "João"},
+		},
+		{
+			name:     "unicode with fallback characters",
+			rtf:      `{\rtf1\ansi\uc1 A\u231?\u227?o {\uc2 p\u233\'3f\'3f} fim\u-3913?}`,
+			want:     "Ação pé fim\uf0b7",
+			metadata: map[string]string{},
+		},
+		{
+			name:     "code page 1251",
+			rtf:      `{\rtf1\ansi\ansicpg1251 \'cf\'f0\'e8\'e2\'e5\'f2}`,
+			want:     "Привет",
+			metadata: map[string]string{},
+		},
+		{
+			name:     "escaped symbols",
+			rtf:      `{\rtf1 \{chaves\} e barra \\ fim\emdash a\_b\line \ldblquote x\rdblquote  \lquote y\rquote}`,
+			want:     "{chaves} e barra \\ fim—a-b\n\"x\" 'y'",
+			metadata: map[string]string{},
+		},
+		{
+			name:     "table rows",
+			rtf:      `{\rtf1 {\trowd Item\cell Valor\cell\row}{\trowd Servi\'e7o\cell 1.500,00\cell\row}}`,
+			want:     "Item | Valor\nServiço | 1.500,00",
+			metadata: map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, metadata := (&RtfExtractor{}).parse([]byte(test.rtf))
+			if text != test.want {
+				t.Errorf("text = %q, want %q", text, test.want)
+			}
+			if !reflect.DeepEqual(metadata, test.metadata) {
+				t.Errorf("metadata = %v, want %v", metadata, test.metadata)
+			}
+		})
+	}
+}
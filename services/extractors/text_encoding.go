@@ -0,0 +1,120 @@
+package extractors
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+const (
+	encodingUTF8        = "utf-8"
+	encodingUTF8BOM     = "utf-8-bom"
+	encodingUTF16LE     = "utf-16le"
+	encodingUTF16BE     = "utf-16be"
+	encodingWindows1252 = "windows-1252"
+	encodingISO88591    = "iso-8859-1"
+
+	utf16SampleSize = 4096
+)
+
+func decodeText(data []byte) (string, string) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(data[3:]), encodingUTF8BOM
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeWith(data[2:], unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)), encodingUTF16LE
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeWith(data[2:], unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)), encodingUTF16BE
+	}
+
+	if endianness, ok := guessUTF16(data); ok {
+		if endianness == unicode.LittleEndian {
+			return decodeWith(data, unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)), encodingUTF16LE
+		}
+		return decodeWith(data, unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)), encodingUTF16BE
+	}
+
+	if utf8.Valid(data) {
+		return string(data), encodingUTF8
+	}
+
+	for _, b := range data {
+		if b >= 0x80 && b <= 0x9F {
+			return decodeWindows1252(data), encodingWindows1252
+		}
+	}
+
+	return decodeWith(data, charmap.ISO8859_1), encodingISO88591
+}
+
+func guessUTF16(data []byte) (unicode.Endianness, bool) {
+	sample := data[:min(len(data), utf16SampleSize)]
+	if len(sample) < 4 {
+		return unicode.LittleEndian, false
+	}
+
+	evenZeros, oddZeros := 0, 0
+	for i, b := range sample {
+		if b != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			evenZeros++
+		} else {
+			oddZeros++
+		}
+	}
+
+	pairs := len(sample) / 2
+	switch {
+	case oddZeros*10 >= pairs*3 && evenZeros*10 < pairs:
+		return unicode.LittleEndian, true
+	case evenZeros*10 >= pairs*3 && oddZeros*10 < pairs:
+		return unicode.BigEndian, true
+	}
+
+	return unicode.LittleEndian, false
+}
+
+func decodeWith(data []byte, enc encoding.Encoding) string {
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return string(data)
+	}
+	return string(decoded)
+}
+
+func decodeCharset(data []byte, charset string) string {
+	charset = strings.TrimSpace(charset)
+	if charset == "" || strings.EqualFold(charset, "utf-8") || strings.EqualFold(charset, "us-ascii") {
+		if utf8.Valid(data) {
+			return string(data)
+		}
+		charset = "windows-1252"
+	}
+
+	charsetEncoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return string(data)
+	}
+
+	return decodeWith(data, charsetEncoding)
+}
+
+func decodeWindows1252(data []byte) string {
+	return decodeWith(data, charmap.Windows1252)
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	charsetEncoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, err
+	}
+	return charsetEncoding.NewDecoder().Reader(input), nil
+}
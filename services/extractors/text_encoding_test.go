@@ -0,0 +1,71 @@
+package extractors
+
+import (
+	"testing"
+
+	"golang.org/x/text/encoding/unicode"
+)
+
+func utf16Bytes(text string, endianness unicode.Endianness) []byte {
+	data, err := unicode.UTF16(endianness, unicode.IgnoreBOM).NewEncoder().Bytes([]byte(text))
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func TestDecodeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		want     string
+		encoding string
+	}{
+		{"utf-8 bom", []byte("\xEF\xBB\xBFRelatório"), "Relatório", encodingUTF8BOM},
+		{"utf-16le bom", append([]byte{0xFF, 0xFE}, utf16Bytes("Ação", unicode.LittleEndian)...), "Ação", encodingUTF16LE},
+		{"utf-16be bom", append([]byte{0xFE, 0xFF}, utf16Bytes("Ação", unicode.BigEndian)...), "Ação", encodingUTF16BE},
+		{"utf-16le without bom", utf16Bytes("Nota fiscal de serviço", unicode.LittleEndian), "Nota fiscal de serviço", encodingUTF16LE},
+		{"utf-16be without bom", utf16Bytes("Nota fiscal de serviço", unicode.BigEndian), "Nota fiscal de serviço", encodingUTF16BE},
+		{"utf-8", []byte("Relatório de comissões"), "Relatório de comissões", encodingUTF8},
+		{"ascii", []byte("total;10"), "total;10", encodingUTF8},
+		{"windows-1252", []byte("Servi\xe7o \x96 \x93m\xeas\x94 \x80 10"), "Serviço – “mês” € 10", encodingWindows1252},
+		{"iso-8859-1", []byte("Ger\xeancia de Opera\xe7\xf5es"), "Gerência de Operações", encodingISO88591},
+		{"short latin-1 portuguese", []byte("A\xe7\xe3o"), "Ação", encodingISO88591},
+		{"empty", nil, "", encodingUTF8},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, encoding := decodeText(test.data)
+			if got != test.want || encoding != test.encoding {
+				t.Errorf("decodeText() = %q, %q; want %q, %q", got, encoding, test.want, test.encoding)
+			}
+		})
+	}
+}
+
+func TestGuessUTF16(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		endianness unicode.Endianness
+		ok         bool
+	}{
+		{"little endian", utf16Bytes("Relatório mensal", unicode.LittleEndian), unicode.LittleEndian, true},
+		{"big endian", utf16Bytes("Relatório mensal", unicode.BigEndian), unicode.BigEndian, true},
+		{"too short", []byte{'A', 0}, unicode.LittleEndian, false},
+		{"latin-1 text", []byte("Fun\xe7\xe3o p\xfablica"), unicode.LittleEndian, false},
+		{"utf-8 text", []byte("Relatório de comissões"), unicode.LittleEndian, false},
+		{"zeros on both sides", []byte{0, 0, 0, 0, 'a', 'b', 0, 0}, unicode.LittleEndian, false},
+		{"few zeros", []byte("abcdefghij\x00klmnopqrst"), unicode.LittleEndian, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			endianness, ok := guessUTF16(test.data)
+			if ok != test.ok || (ok && endianness != test.endianness) {
+				t.Errorf("guessUTF16() = %v, %v; want %v, %v", endianness, ok, test.endianness, test.ok)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"relatorios/models"
+	"strings"
 )
 
 type TextFileExtractor struct{}
@@ -14,14 +15,17 @@ func (e *TextFileExtractor) ExtractText(filePath string) (models.DocumentMetadat
 		return models.DocumentMetadata{}, err
 	}
 
+	text, encoding := decodeText(data)
+
 	return models.DocumentMetadata{
 		Filename: filepath.Base(filePath),
-		Text:     string(data),
+		Text:     text,
+		Metadata: map[string]string{"encoding": encoding},
 	}, nil
 }
 
 func (e *TextFileExtractor) IsSupportedFormat(filePath string) bool {
-	ext := filepath.Ext(filePath)
+	ext := strings.ToLower(filepath.Ext(filePath))
 	return ext == ".txt"
 }
 
@@ -4,34 +4,45 @@ import (
 	"fmt"
 	"path/filepath"
 	"relatorios/models"
-
-	"github.com/unidoc/unioffice/document"
+	"strings"
 )
 
 type WordExtractor struct{}
 
 func (e *WordExtractor) ExtractText(filePath string) (models.DocumentMetadata, error) {
-	doc, err := document.Open(filePath)
+	if isCompoundFile(filePath) {
+		text, err := readLegacyWordText(filePath)
+		if err != nil {
+			return models.DocumentMetadata{}, fmt.Errorf("failed to read Word 97-2003 document: %w", err)
+		}
+
+		return models.DocumentMetadata{
+			Filename: filepath.Base(filePath),
+			Text:     text,
+		}, nil
+	}
+
+	sections, err := readDocxSections(filePath)
 	if err != nil {
 		return models.DocumentMetadata{}, fmt.Errorf("failed to open Word document: %w", err)
 	}
 
-	var text string
-	for _, para := range doc.Paragraphs() {
-		for _, run := range para.Runs() {
-			text += run.Text()
-		}
-		text += "\n"
-	}
+	pages := splitBodyPages(sections)
+
+	info := &models.DocumentInfo{}
+	readPackageProperties(filePath, info)
 
 	return models.DocumentMetadata{
 		Filename: filepath.Base(filePath),
-		Text:     text,
+		Text:     formatDocumentSections(sections),
+		Sections: sections,
+		Pages:    pages,
+		Info:     info,
 	}, nil
 }
 
 func (e *WordExtractor) IsSupportedFormat(filePath string) bool {
-	ext := filepath.Ext(filePath)
+	ext := strings.ToLower(filepath.Ext(filePath))
 	return ext == ".docx" || ext == ".doc"
 }
 
@@ -0,0 +1,73 @@
+package extractors
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+)
+
+type xmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlNode
+	text     string
+}
+
+func (n *xmlNode) attr(name string) string {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func (n *xmlNode) attrNS(space, name string) string {
+	for _, attr := range n.attrs {
+		if attr.Name.Space == space && attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func (n *xmlNode) find(name string) []*xmlNode {
+	var found []*xmlNode
+	for _, child := range n.children {
+		if child.name == name {
+			found = append(found, child)
+			continue
+		}
+		found = append(found, child.find(name)...)
+	}
+	return found
+}
+
+func parseXMLTree(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return root, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parent := stack[len(stack)-1]
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: element.Name.Local, attrs: element.Attr}
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			parent.children = append(parent.children, &xmlNode{text: string(element)})
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"relatorios/models"
+	"sort"
+)
+
+type FeedbackService struct {
+	processingService *DocumentProcessingService
+}
+
+func NewFeedbackService(processingService *DocumentProcessingService) *FeedbackService {
+	return &FeedbackService{
+		processingService: processingService,
+	}
+}
+
+func (s *FeedbackService) BuildCorrectionReport() (*models.CorrectionReport, error) {
+	examples, err := s.processingService.GetLabeledExamples()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.CorrectionReport{
+		TotalExamples:      len(examples),
+		Confusions:         make([]models.DocumentMove, 0),
+		MisleadingKeywords: make([]models.MisleadingKeyword, 0),
+	}
+
+	analyzeService := s.processingService.GetAnalyzeService()
+	confusions := make(map[string]*models.DocumentMove)
+	keywords := make(map[string]*models.MisleadingKeyword)
+
+	for _, example := range examples {
+		if example.Predicted == example.Label {
+			continue
+		}
+
+		report.Corrections++
+
+		confusionKey := example.Predicted + "\x00" + example.Label
+		confusion, exists := confusions[confusionKey]
+		if !exists {
+			confusion = &models.DocumentMove{
+				FromType:    example.Predicted,
+				ToType:      example.Label,
+				SampleFiles: make([]string, 0),
+			}
+			confusions[confusionKey] = confusion
+		}
+
+		confusion.Count++
+		if len(confusion.SampleFiles) < maxSampleFiles {
+			confusion.SampleFiles = append(confusion.SampleFiles, example.Filename)
+		}
+
+		if analyzeService == nil {
+			continue
+		}
+
+		document, err := s.processingService.ExtractDocument(example.Path)
+		if err != nil {
+			report.UnreadableExamples++
+			continue
+		}
+
+		for _, keyword := range analyzeService.MatchKeywords(document.Text, example.Predicted) {
+			keywordKey := example.Predicted + "\x00" + keyword
+			misleading, exists := keywords[keywordKey]
+			if !exists {
+				misleading = &models.MisleadingKeyword{
+					Keyword:      keyword,
+					DocumentType: example.Predicted,
+					SampleFiles:  make([]string, 0),
+				}
+				keywords[keywordKey] = misleading
+			}
+
+			misleading.Count++
+			if len(misleading.SampleFiles) < maxSampleFiles {
+				misleading.SampleFiles = append(misleading.SampleFiles, example.Filename)
+			}
+		}
+	}
+
+	for _, confusion := range confusions {
+		report.Confusions = append(report.Confusions, *confusion)
+	}
+
+	for _, misleading := range keywords {
+		report.MisleadingKeywords = append(report.MisleadingKeywords, *misleading)
+	}
+
+	sort.Slice(report.Confusions, func(i, j int) bool {
+		if report.Confusions[i].Count != report.Confusions[j].Count {
+			return report.Confusions[i].Count > report.Confusions[j].Count
+		}
+		return report.Confusions[i].FromType+report.Confusions[i].ToType < report.Confusions[j].FromType+report.Confusions[j].ToType
+	})
+
+	sort.Slice(report.MisleadingKeywords, func(i, j int) bool {
+		if report.MisleadingKeywords[i].Count != report.MisleadingKeywords[j].Count {
+			return report.MisleadingKeywords[i].Count > report.MisleadingKeywords[j].Count
+		}
+		return report.MisleadingKeywords[i].Keyword < report.MisleadingKeywords[j].Keyword
+	})
+
+	return report, nil
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"relatorios/interfaces"
+	"relatorios/models"
+	"relatorios/services/extractors"
+	"strings"
+	"testing"
+)
+
+type trainingClassifier struct {
+	analyzeService interfaces.AnalyzeService
+	trained        []models.DocumentMetadata
+}
+
+func (c *trainingClassifier) Classify(document models.DocumentMetadata) (models.DocumentMetadata, error) {
+	result := c.analyzeService.ExecuteDocument(document)
+	document.Classification = &result.Classification
+	return document, nil
+}
+
+func (c *trainingClassifier) GetClassifierName() string {
+	return "Training Classifier"
+}
+
+func (c *trainingClassifier) GetAnalyzeService() interfaces.AnalyzeService {
+	return c.analyzeService
+}
+
+func (c *trainingClassifier) Train(documents []models.DocumentMetadata) error {
+	c.trained = append(c.trained, documents...)
+	return nil
+}
+
+func newFeedbackTestService(t *testing.T) (*DocumentProcessingService, *trainingClassifier, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	rulesFile := filepath.Join(dir, "rules.json")
+	rules := `[
+		{"type": "Invoice", "keywords": ["nota fiscal", "valor total"]},
+		{"type": "Contract", "keywords": ["contratante", "clausula"]}
+	]`
+	writeTestFile(t, rulesFile, rules)
+
+	classifier := &trainingClassifier{analyzeService: NewAnalyzeDocumentService(rulesFile)}
+	service := NewDocumentProcessingService(extractors.NewDocumentExtractorFactory(), classifier, models.ProcessingConfig{
+		OutputDirectory:     filepath.Join(dir, "output"),
+		ReviewQueueFile:     filepath.Join(dir, "queue.json"),
+		LabeledExamplesFile: filepath.Join(dir, "examples.json"),
+	})
+
+	return service, classifier, dir
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildCorrectionReport(t *testing.T) {
+	service, _, dir := newFeedbackTestService(t)
+
+	misfiled := filepath.Join(dir, "misfiled.txt")
+	writeTestFile(t, misfiled, "Contrato com nota fiscal anexa. A contratante aceita a clausula.")
+
+	examples := []models.LabeledExample{
+		{Path: misfiled, Filename: "misfiled.txt", Predicted: "Invoice", Label: "Contract"},
+		{Path: filepath.Join(dir, "gone.txt"), Filename: "gone.txt", Predicted: "Invoice", Label: "Contract"},
+		{Path: misfiled, Filename: "confirmed.txt", Predicted: "Contract", Label: "Contract"},
+	}
+	if err := models.SaveLabeledExamplesToJSON(filepath.Join(dir, "examples.json"), examples); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := NewFeedbackService(service).BuildCorrectionReport()
+	if err != nil {
+		t.Fatalf("BuildCorrectionReport: %v", err)
+	}
+
+	if report.TotalExamples != 3 || report.Corrections != 2 || report.UnreadableExamples != 1 {
+		t.Errorf("totals = %d/%d/%d, want 3 examples, 2 corrections, 1 unreadable",
+			report.TotalExamples, report.Corrections, report.UnreadableExamples)
+	}
+
+	if len(report.Confusions) != 1 {
+		t.Fatalf("confusions = %+v, want one Invoice -> Contract entry", report.Confusions)
+	}
+	confusion := report.Confusions[0]
+	if confusion.FromType != "Invoice" || confusion.ToType != "Contract" || confusion.Count != 2 {
+		t.Errorf("confusion = %+v, want Invoice -> Contract twice", confusion)
+	}
+
+	if len(report.MisleadingKeywords) != 1 {
+		t.Fatalf("misleading keywords = %+v, want only nota fiscal", report.MisleadingKeywords)
+	}
+	misleading := report.MisleadingKeywords[0]
+	if misleading.Keyword != "nota fiscal" || misleading.DocumentType != "Invoice" || misleading.Count != 1 {
+		t.Errorf("misleading keyword = %+v, want nota fiscal for Invoice", misleading)
+	}
+}
+
+func TestCorrectClassificationKeepsExistingFiles(t *testing.T) {
+	service, classifier, dir := newFeedbackTestService(t)
+
+	existing := filepath.Join(dir, "output", "Contract", "scan.txt")
+	writeTestFile(t, existing, "already filed")
+
+	filed := filepath.Join(dir, "output", "Invoice", "scan.txt")
+	writeTestFile(t, filed, "A contratante aceita a clausula.")
+
+	document := models.DocumentMetadata{
+		Filename:       "scan.txt",
+		Text:           "A contratante aceita a clausula.",
+		Classification: &models.DocumentClassification{DocumentType: "Invoice"},
+	}
+
+	correctedPath, err := service.CorrectClassification(document, filed, "Contract")
+	if err != nil {
+		t.Fatalf("CorrectClassification: %v", err)
+	}
+
+	if filepath.Base(correctedPath) != "scan (2).txt" {
+		t.Errorf("corrected path = %s, want scan (2).txt", correctedPath)
+	}
+	if data, err := os.ReadFile(existing); err != nil || string(data) != "already filed" {
+		t.Errorf("existing file was overwritten: %q, %v", data, err)
+	}
+	if _, err := os.Stat(filed); !os.IsNotExist(err) {
+		t.Errorf("original file still present: %v", err)
+	}
+
+	if len(classifier.trained) != 1 {
+		t.Fatalf("trained %d documents, want only the corrected one", len(classifier.trained))
+	}
+	if classifier.trained[0].Classification.DocumentType != "Contract" || classifier.trained[0].Text != document.Text {
+		t.Errorf("trained document = %+v", classifier.trained[0])
+	}
+
+	if err := os.Remove(correctedPath); err != nil {
+		t.Fatal(err)
+	}
+	count, err := service.RetrainClassifier()
+	if err != nil {
+		t.Fatalf("RetrainClassifier: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("retrained on %d documents, want the cached correction", count)
+	}
+}
+
+func TestCorrectClassificationRejectsUnsafeTypes(t *testing.T) {
+	service, _, dir := newFeedbackTestService(t)
+
+	filed := filepath.Join(dir, "output", "Invoice", "scan.txt")
+	writeTestFile(t, filed, "texto")
+
+	document := models.DocumentMetadata{
+		Filename:       "scan.txt",
+		Text:           "texto",
+		Classification: &models.DocumentClassification{DocumentType: "Invoice"},
+	}
+
+	for _, documentType := range []string{"", "..", "../outside", "a/b", `a\b`} {
+		if _, err := service.CorrectClassification(document, filed, documentType); err == nil {
+			t.Errorf("type %q was accepted", documentType)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "outside") {
+			t.Errorf("created %s outside the output directory", entry.Name())
+		}
+	}
+	if _, err := os.Stat(filed); err != nil {
+		t.Errorf("rejected correction moved the file: %v", err)
+	}
+}
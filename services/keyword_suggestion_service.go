@@ -0,0 +1,210 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"relatorios/models"
+	"sort"
+	"strings"
+)
+
+const (
+	maxSuggestionsPerType  = 10
+	minSuggestionPrecision = 0.6
+)
+
+type KeywordSuggestionService struct {
+	processingService *DocumentProcessingService
+}
+
+func NewKeywordSuggestionService(processingService *DocumentProcessingService) *KeywordSuggestionService {
+	return &KeywordSuggestionService{
+		processingService: processingService,
+	}
+}
+
+func (s *KeywordSuggestionService) SuggestKeywords(samplesDir string, rules []models.DocumentRule) ([]models.TypeKeywordSuggestions, error) {
+	samples, err := s.processingService.BuildLabeledIndex(samplesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	documentTerms := make(map[string][]map[string]bool)
+	var types []string
+
+	for _, document := range samples {
+		documentType := document.Classification.DocumentType
+		if _, exists := documentTerms[documentType]; !exists {
+			types = append(types, documentType)
+		}
+		documentTerms[documentType] = append(documentTerms[documentType], s.extractTerms(document.Text))
+	}
+
+	if len(types) < 2 {
+		return nil, fmt.Errorf("at least two sample folders with supported documents are required, found %d", len(types))
+	}
+
+	documentFrequency := make(map[string]map[string]int)
+	for _, documentType := range types {
+		frequencies := make(map[string]int)
+		for _, terms := range documentTerms[documentType] {
+			for term := range terms {
+				frequencies[term]++
+			}
+		}
+		documentFrequency[documentType] = frequencies
+	}
+
+	existingKeywords := make(map[string]map[string]bool)
+	for _, rule := range rules {
+		keywords := make(map[string]bool)
+		for _, keyword := range rule.Keywords {
+			keywords[strings.ToLower(keyword)] = true
+		}
+		existingKeywords[rule.Type] = keywords
+	}
+
+	results := make([]models.TypeKeywordSuggestions, 0, len(types))
+
+	for _, documentType := range types {
+		typeCount := len(documentTerms[documentType])
+		otherCount := 0
+		for _, otherType := range types {
+			if otherType != documentType {
+				otherCount += len(documentTerms[otherType])
+			}
+		}
+
+		minFrequency := 2
+		if typeCount < 4 {
+			minFrequency = 1
+		}
+
+		suggestions := make([]models.KeywordSuggestion, 0)
+
+		for term, frequency := range documentFrequency[documentType] {
+			if frequency < minFrequency || existingKeywords[documentType][term] {
+				continue
+			}
+
+			otherFrequency := 0
+			for _, otherType := range types {
+				if otherType != documentType {
+					otherFrequency += documentFrequency[otherType][term]
+				}
+			}
+
+			precision := s.precision(frequency, typeCount, otherFrequency, otherCount)
+			if precision < minSuggestionPrecision {
+				continue
+			}
+
+			suggestions = append(suggestions, models.KeywordSuggestion{
+				Keyword:   term,
+				Score:     s.logOdds(frequency, typeCount, otherFrequency, otherCount),
+				Precision: precision,
+				Coverage:  float64(frequency) / float64(typeCount),
+			})
+		}
+
+		sort.Slice(suggestions, func(i, j int) bool {
+			if suggestions[i].Score != suggestions[j].Score {
+				return suggestions[i].Score > suggestions[j].Score
+			}
+			return suggestions[i].Keyword < suggestions[j].Keyword
+		})
+
+		suggestions = s.removeRedundantTerms(suggestions)
+		if len(suggestions) > maxSuggestionsPerType {
+			suggestions = suggestions[:maxSuggestionsPerType]
+		}
+
+		results = append(results, models.TypeKeywordSuggestions{
+			Type:        documentType,
+			SampleCount: typeCount,
+			Suggestions: suggestions,
+		})
+	}
+
+	return results, nil
+}
+
+func (s *KeywordSuggestionService) BuildDraftRules(rules []models.DocumentRule, suggestions []models.TypeKeywordSuggestions) []models.DocumentRule {
+	draft := make([]models.DocumentRule, 0, len(rules)+len(suggestions))
+	positions := make(map[string]int)
+
+	for _, rule := range rules {
+		positions[rule.Type] = len(draft)
+		rule.Keywords = append([]string{}, rule.Keywords...)
+		draft = append(draft, rule)
+	}
+
+	for _, typeSuggestions := range suggestions {
+		position, exists := positions[typeSuggestions.Type]
+		if !exists {
+			position = len(draft)
+			positions[typeSuggestions.Type] = position
+			draft = append(draft, models.DocumentRule{
+				Type:     typeSuggestions.Type,
+				Keywords: []string{},
+			})
+		}
+
+		for _, suggestion := range typeSuggestions.Suggestions {
+			draft[position].Keywords = append(draft[position].Keywords, suggestion.Keyword)
+		}
+	}
+
+	return draft
+}
+
+func (s *KeywordSuggestionService) extractTerms(text string) map[string]bool {
+	terms := make(map[string]bool)
+	tokens := tokenize(text)
+
+	for i, token := range tokens {
+		if !isCandidateTerm(token) {
+			continue
+		}
+
+		terms[token] = true
+
+		if i+1 < len(tokens) && isCandidateTerm(tokens[i+1]) {
+			terms[token+" "+tokens[i+1]] = true
+		}
+	}
+
+	return terms
+}
+
+func (s *KeywordSuggestionService) precision(frequency, total, otherFrequency, otherTotal int) float64 {
+	rate := float64(frequency) / float64(total)
+	otherRate := float64(otherFrequency) / float64(otherTotal)
+	return rate / (rate + otherRate)
+}
+
+func (s *KeywordSuggestionService) logOdds(frequency, total, otherFrequency, otherTotal int) float64 {
+	inType := (float64(frequency) + 0.5) / (float64(total-frequency) + 0.5)
+	inOthers := (float64(otherFrequency) + 0.5) / (float64(otherTotal-otherFrequency) + 0.5)
+	return math.Log(inType) - math.Log(inOthers)
+}
+
+func (s *KeywordSuggestionService) removeRedundantTerms(suggestions []models.KeywordSuggestion) []models.KeywordSuggestion {
+	kept := make([]models.KeywordSuggestion, 0, len(suggestions))
+
+	for _, suggestion := range suggestions {
+		redundant := false
+		for _, existing := range kept {
+			if existing.Score >= suggestion.Score && strings.Contains(suggestion.Keyword, existing.Keyword) {
+				redundant = true
+				break
+			}
+		}
+
+		if !redundant {
+			kept = append(kept, suggestion)
+		}
+	}
+
+	return kept
+}
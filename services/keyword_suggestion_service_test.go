@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeywordSuggestionPrecisionComparesClassRates(t *testing.T) {
+	service := &KeywordSuggestionService{}
+
+	tests := []struct {
+		name                                         string
+		frequency, total, otherFrequency, otherTotal int
+		want                                         float64
+	}{
+		{"only in the type", 3, 3, 0, 50, 1},
+		{"same rate everywhere", 2, 4, 25, 50, 0.5},
+		{"small class against a large one", 2, 2, 2, 8, 0.8},
+		{"more common elsewhere", 1, 10, 5, 10, 1.0 / 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := service.precision(test.frequency, test.total, test.otherFrequency, test.otherTotal)
+			if math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("precision = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestKeywordSuggestionLogOddsRanking(t *testing.T) {
+	service := &KeywordSuggestionService{}
+
+	ranked := []struct {
+		name                                         string
+		frequency, total, otherFrequency, otherTotal int
+	}{
+		{"in every sample and nowhere else", 10, 10, 0, 10},
+		{"in most samples and nowhere else", 7, 10, 0, 10},
+		{"in most samples and a few others", 7, 10, 2, 10},
+		{"as common as elsewhere", 5, 10, 5, 10},
+		{"more common elsewhere", 2, 10, 8, 10},
+	}
+
+	previous := math.Inf(1)
+	for _, term := range ranked {
+		score := service.logOdds(term.frequency, term.total, term.otherFrequency, term.otherTotal)
+		if score >= previous {
+			t.Errorf("%s scored %v, not below the previous term (%v)", term.name, score, previous)
+		}
+		previous = score
+	}
+
+	if score := service.logOdds(5, 10, 5, 10); math.Abs(score) > 1e-9 {
+		t.Errorf("equal rates scored %v, want 0", score)
+	}
+}
+
+func TestSuggestKeywordsForSmallClass(t *testing.T) {
+	processingService, _, dir := newFeedbackTestService(t)
+	samplesDir := filepath.Join(dir, "samples")
+
+	for i := 0; i < 8; i++ {
+		text := fmt.Sprintf("Nota fiscal eletronica numero %d com valor total", i)
+		if i < 2 {
+			text += " e pagamento"
+		}
+		writeTestFile(t, filepath.Join(samplesDir, "Invoice", fmt.Sprintf("nota%d.txt", i)), text)
+	}
+	writeTestFile(t, filepath.Join(samplesDir, "Receipt", "recibo1.txt"), "Recibo de pagamento referente ao aluguel")
+	writeTestFile(t, filepath.Join(samplesDir, "Receipt", "recibo2.txt"), "Recibo de pagamento referente ao servico")
+
+	service := NewKeywordSuggestionService(processingService)
+	results, err := service.SuggestKeywords(samplesDir, processingService.GetAnalyzeService().GetRules())
+	if err != nil {
+		t.Fatalf("SuggestKeywords: %v", err)
+	}
+
+	suggested := make(map[string]map[string]float64)
+	for _, result := range results {
+		suggested[result.Type] = make(map[string]float64)
+		for _, suggestion := range result.Suggestions {
+			suggested[result.Type][suggestion.Keyword] = suggestion.Precision
+		}
+	}
+
+	if precision, ok := suggested["Receipt"]["pagamento"]; !ok || math.Abs(precision-0.8) > 1e-9 {
+		t.Errorf("Receipt suggestions = %v, want pagamento with precision 0.8", suggested["Receipt"])
+	}
+	if _, ok := suggested["Receipt"]["recibo"]; !ok {
+		t.Errorf("Receipt suggestions = %v, want recibo", suggested["Receipt"])
+	}
+	if _, ok := suggested["Invoice"]["nota fiscal"]; ok {
+		t.Errorf("Invoice suggestions = %v, include an existing keyword", suggested["Invoice"])
+	}
+	if _, ok := suggested["Invoice"]["eletronica"]; !ok {
+		t.Errorf("Invoice suggestions = %v, want eletronica", suggested["Invoice"])
+	}
+}
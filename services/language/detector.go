@@ -0,0 +1,143 @@
+package language
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	Portuguese = "por"
+	English    = "eng"
+	Spanish    = "spa"
+
+	profileSize     = 300
+	minLetterCount  = 20
+	maxDetectedRune = 5000
+
+	maxRelativeDistance = 0.65
+	minRelativeMargin   = 0.08
+)
+
+var profiles = buildProfiles()
+
+func Detect(text string) string {
+	if countLetters(text) < minLetterCount {
+		return ""
+	}
+
+	ranks := rankTrigrams(text)
+
+	bestLanguage := ""
+	bestDistance := -1
+	runnerUpDistance := -1
+
+	for _, code := range SupportedLanguages() {
+		distance := outOfPlaceDistance(ranks, profiles[code])
+		if bestDistance < 0 || distance < bestDistance {
+			runnerUpDistance = bestDistance
+			bestDistance = distance
+			bestLanguage = code
+		} else if runnerUpDistance < 0 || distance < runnerUpDistance {
+			runnerUpDistance = distance
+		}
+	}
+
+	maxDistance := float64(len(ranks) * profileSize)
+	if maxDistance == 0 || float64(bestDistance)/maxDistance > maxRelativeDistance {
+		return ""
+	}
+	if runnerUpDistance >= 0 && float64(runnerUpDistance-bestDistance)/maxDistance < minRelativeMargin {
+		return ""
+	}
+
+	return bestLanguage
+}
+
+func SupportedLanguages() []string {
+	return []string{Portuguese, English, Spanish}
+}
+
+func buildProfiles() map[string]map[string]int {
+	built := make(map[string]map[string]int)
+	for code, text := range trainingTexts {
+		built[code] = rankTrigrams(text)
+	}
+	return built
+}
+
+func rankTrigrams(text string) map[string]int {
+	counts := make(map[string]int)
+
+	for _, word := range words(text) {
+		padded := []rune("_" + word + "_")
+		for i := 0; i+3 <= len(padded); i++ {
+			counts[string(padded[i:i+3])]++
+		}
+	}
+
+	trigrams := make([]string, 0, len(counts))
+	for trigram := range counts {
+		trigrams = append(trigrams, trigram)
+	}
+
+	sort.Slice(trigrams, func(i, j int) bool {
+		if counts[trigrams[i]] != counts[trigrams[j]] {
+			return counts[trigrams[i]] > counts[trigrams[j]]
+		}
+		return trigrams[i] < trigrams[j]
+	})
+
+	if len(trigrams) > profileSize {
+		trigrams = trigrams[:profileSize]
+	}
+
+	ranks := make(map[string]int, len(trigrams))
+	for rank, trigram := range trigrams {
+		ranks[trigram] = rank
+	}
+
+	return ranks
+}
+
+func outOfPlaceDistance(document map[string]int, profile map[string]int) int {
+	distance := 0
+	for trigram, rank := range document {
+		profileRank, exists := profile[trigram]
+		if !exists {
+			distance += profileSize
+			continue
+		}
+
+		if rank > profileRank {
+			distance += rank - profileRank
+		} else {
+			distance += profileRank - rank
+		}
+	}
+	return distance
+}
+
+func words(text string) []string {
+	runes := []rune(strings.ToLower(text))
+	if len(runes) > maxDetectedRune {
+		runes = runes[:maxDetectedRune]
+	}
+
+	return strings.FieldsFunc(string(runes), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+func countLetters(text string) int {
+	count := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			count++
+			if count >= minLetterCount {
+				break
+			}
+		}
+	}
+	return count
+}
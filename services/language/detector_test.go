@@ -0,0 +1,30 @@
+package language
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"portuguese", "Recebemos da empresa o valor referente à prestação de serviços de manutenção do mês de abril, conforme nota fiscal.", Portuguese},
+		{"portuguese short", "Nota fiscal de serviço valor total", Portuguese},
+		{"english", "We received from the company the amount for maintenance services provided during April, according to the invoice.", English},
+		{"spanish", "Recibimos de la empresa el importe correspondiente a los servicios de mantenimiento del mes de abril, según la factura.", Spanish},
+		{"german", "Wir haben von der Firma den Betrag für die im April erbrachten Wartungsleistungen gemäß der Rechnung erhalten.", ""},
+		{"french", "Nous avons reçu de la société le montant correspondant aux services de maintenance fournis en avril, selon la facture.", ""},
+		{"italian", "Abbiamo ricevuto dalla società l'importo relativo ai servizi di manutenzione forniti nel mese di aprile, secondo la fattura.", ""},
+		{"table", "ID QTD UN VL 001 12 UN 45,00 002 3 CX 12,50 003 7 KG 3,20 SKU ABC XYZ QWE RTY UIO", ""},
+		{"ocr noise", "lI1 |l ~~ rn mn ii Il1l ,. ;; ' fj xq zk wv qq ii jj ll kk xx zz vv ww yy hh", ""},
+		{"too short", "nota fiscal", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Detect(test.text); got != test.want {
+				t.Errorf("Detect() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
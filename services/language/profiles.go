@@ -0,0 +1,48 @@
+package language
+
+var trainingTexts = map[string]string{
+	Portuguese: `O presente contrato é firmado entre as partes abaixo qualificadas, que
+acordam as cláusulas e condições seguintes. A empresa contratante se obriga a
+efetuar o pagamento do valor total na data de vencimento, conforme a nota
+fiscal emitida pelo prestador de serviços. Recebi da empresa a quantia
+referente ao pagamento dos serviços prestados no mês de março, para que não
+haja dúvidas dou plena quitação. Não é possível emitir o documento sem o
+número do CNPJ do emitente e do destinatário. Os impostos são calculados sobre
+o valor da operação, e a alíquota do ICMS depende do estado de destino. Este
+relatório apresenta os resultados do período e as principais ações que foram
+realizadas pela equipe, com informações sobre as vendas, os custos e as
+despesas. Em caso de rescisão, a parte que der causa pagará multa de dez por
+cento sobre o valor do contrato. Fica eleito o foro da comarca da cidade para
+dirimir quaisquer questões oriundas deste instrumento. São Paulo, dia de hoje.
+Não há mais nada a declarar, então assinam as partes em duas vias de igual teor.`,
+
+	English: `This agreement is made between the parties identified below, who agree
+to the following terms and conditions. The customer shall pay the total amount
+due on the invoice date, as stated on the invoice issued by the service
+provider. Received from the company the sum of the amount described for the
+services provided during the month of March, with full and final settlement.
+The invoice cannot be issued without the tax identification number of the
+seller and the buyer. Taxes are calculated on the value of the transaction and
+the rate depends on the state where the goods are shipped. This report presents
+the results for the period and the main actions that were taken by the team,
+with information about sales, costs and expenses. In the event of termination,
+the party at fault shall pay a penalty of ten percent of the contract value.
+The courts of the city shall have jurisdiction over any dispute arising from
+this agreement. There is nothing further to declare, so the parties sign this
+document in two copies of equal content and form, witnessed by the undersigned.`,
+
+	Spanish: `El presente contrato se celebra entre las partes abajo identificadas, que
+acuerdan las cláusulas y condiciones siguientes. La empresa contratante se
+obliga a efectuar el pago del importe total en la fecha de vencimiento, según
+la factura emitida por el proveedor de servicios. Recibí de la empresa la
+cantidad correspondiente al pago de los servicios prestados durante el mes de
+marzo, para que no haya dudas otorgo el más amplio finiquito. No es posible
+emitir la factura sin el número de identificación fiscal del emisor y del
+receptor. Los impuestos se calculan sobre el valor de la operación y la tasa
+del IVA depende de la región de destino. Este informe presenta los resultados
+del período y las principales acciones que fueron realizadas por el equipo, con
+información sobre las ventas, los costos y los gastos. En caso de rescisión, la
+parte que haya dado causa pagará una multa del diez por ciento del valor del
+contrato. Las partes se someten a los tribunales de la ciudad para resolver
+cualquier controversia. No habiendo nada más que declarar, firman las partes.`,
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"relatorios/models"
+	"time"
+)
+
+const reviewPreviewLength = 600
+
+type ReviewQueueService struct {
+	queueFile    string
+	examplesFile string
+}
+
+func NewReviewQueueService(queueFile string, examplesFile string) *ReviewQueueService {
+	return &ReviewQueueService{
+		queueFile:    queueFile,
+		examplesFile: examplesFile,
+	}
+}
+
+func (s *ReviewQueueService) Enqueue(sourcePath string, document models.DocumentMetadata) error {
+	items, err := models.LoadReviewQueueFromJSON(s.queueFile)
+	if err != nil {
+		return err
+	}
+
+	absPath, err := filepath.Abs(sourcePath)
+	if err != nil {
+		absPath = sourcePath
+	}
+
+	item := models.ReviewItem{
+		ID:             hashText(absPath)[:12],
+		SourcePath:     absPath,
+		Filename:       document.Filename,
+		TextHash:       hashText(document.Text),
+		TextPreview:    s.preview(document.Text),
+		Classification: *document.Classification,
+		QueuedAt:       time.Now(),
+	}
+
+	for i, existing := range items {
+		if existing.ID == item.ID {
+			items[i] = item
+			return models.SaveReviewQueueToJSON(s.queueFile, items)
+		}
+	}
+
+	items = append(items, item)
+	return models.SaveReviewQueueToJSON(s.queueFile, items)
+}
+
+func (s *ReviewQueueService) GetPending() ([]models.ReviewItem, error) {
+	return models.LoadReviewQueueFromJSON(s.queueFile)
+}
+
+func (s *ReviewQueueService) Remove(id string) (models.ReviewItem, error) {
+	items, err := models.LoadReviewQueueFromJSON(s.queueFile)
+	if err != nil {
+		return models.ReviewItem{}, err
+	}
+
+	for i, item := range items {
+		if item.ID == id {
+			items = append(items[:i], items[i+1:]...)
+			return item, models.SaveReviewQueueToJSON(s.queueFile, items)
+		}
+	}
+
+	return models.ReviewItem{}, fmt.Errorf("review item not found: %s", id)
+}
+
+func (s *ReviewQueueService) RecordExample(example models.LabeledExample) error {
+	examples, err := models.LoadLabeledExamplesFromJSON(s.examplesFile)
+	if err != nil {
+		return err
+	}
+
+	example.CreatedAt = time.Now()
+	examples = append(examples, example)
+
+	return models.SaveLabeledExamplesToJSON(s.examplesFile, examples)
+}
+
+func (s *ReviewQueueService) preview(text string) string {
+	runes := []rune(text)
+	if len(runes) > reviewPreviewLength {
+		return string(runes[:reviewPreviewLength]) + "..."
+	}
+	return text
+}
+
+func hashText(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
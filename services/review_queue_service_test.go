@@ -0,0 +1,156 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"relatorios/models"
+	"testing"
+)
+
+func TestReviewQueueEnqueueReplacesAndRemoves(t *testing.T) {
+	dir := t.TempDir()
+	queue := NewReviewQueueService(filepath.Join(dir, "queue.json"), filepath.Join(dir, "examples.json"))
+
+	document := models.DocumentMetadata{
+		Filename:       "invoice.pdf",
+		Text:           "nota fiscal",
+		Classification: &models.DocumentClassification{DocumentType: "Invoice", Confidence: 0.4},
+	}
+
+	sourcePath := filepath.Join(dir, "invoice.pdf")
+	if err := queue.Enqueue(sourcePath, document); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	document.Text = "nota fiscal eletronica"
+	if err := queue.Enqueue(sourcePath, document); err != nil {
+		t.Fatalf("Enqueue again: %v", err)
+	}
+
+	other := document
+	other.Filename = "contract.pdf"
+	if err := queue.Enqueue(filepath.Join(dir, "contract.pdf"), other); err != nil {
+		t.Fatalf("Enqueue other: %v", err)
+	}
+
+	pending, err := queue.GetPending()
+	if err != nil {
+		t.Fatalf("GetPending: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("pending = %d items, want 2", len(pending))
+	}
+	if pending[0].TextHash != hashText("nota fiscal eletronica") {
+		t.Errorf("re-enqueued item was not replaced: %+v", pending[0])
+	}
+	if pending[0].Classification.DocumentType != "Invoice" {
+		t.Errorf("classification = %q, want Invoice", pending[0].Classification.DocumentType)
+	}
+
+	removed, err := queue.Remove(pending[0].ID)
+	if err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if removed.Filename != "invoice.pdf" {
+		t.Errorf("removed %q, want invoice.pdf", removed.Filename)
+	}
+
+	if _, err := queue.Remove(pending[0].ID); err == nil {
+		t.Error("removing a missing item should fail")
+	}
+
+	pending, err = NewReviewQueueService(filepath.Join(dir, "queue.json"), "").GetPending()
+	if err != nil {
+		t.Fatalf("GetPending after reload: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Filename != "contract.pdf" {
+		t.Errorf("pending after reload = %+v, want only contract.pdf", pending)
+	}
+}
+
+func TestReviewQueueRecordExamplePersists(t *testing.T) {
+	dir := t.TempDir()
+	examplesFile := filepath.Join(dir, "nested", "examples.json")
+	queue := NewReviewQueueService(filepath.Join(dir, "queue.json"), examplesFile)
+
+	examples := []models.LabeledExample{
+		{Path: "a.pdf", Filename: "a.pdf", TextHash: "1", Predicted: "Invoice", Label: "Contract", Source: "correction"},
+		{Path: "b.pdf", Filename: "b.pdf", TextHash: "2", Predicted: "Receipt", Label: "Receipt", Source: "review"},
+	}
+	for _, example := range examples {
+		if err := queue.RecordExample(example); err != nil {
+			t.Fatalf("RecordExample: %v", err)
+		}
+	}
+
+	loaded, err := models.LoadLabeledExamplesFromJSON(examplesFile)
+	if err != nil {
+		t.Fatalf("LoadLabeledExamplesFromJSON: %v", err)
+	}
+	if len(loaded) != len(examples) {
+		t.Fatalf("loaded %d examples, want %d", len(loaded), len(examples))
+	}
+
+	for i, example := range loaded {
+		if example.CreatedAt.IsZero() {
+			t.Errorf("example %d has no creation time", i)
+		}
+		example.CreatedAt = examples[i].CreatedAt
+		if example != examples[i] {
+			t.Errorf("example %d = %+v, want %+v", i, example, examples[i])
+		}
+	}
+
+	missing, err := models.LoadLabeledExamplesFromJSON(filepath.Join(dir, "missing.json"))
+	if err != nil || len(missing) != 0 {
+		t.Errorf("missing file = %v, %v; want empty list", missing, err)
+	}
+}
+
+func TestResolveReviewFilesStagedEntries(t *testing.T) {
+	service, _, dir := newFeedbackTestService(t)
+	service.config.ReviewThreshold = 2
+
+	existing := filepath.Join(dir, "output", "Invoice", "nota.txt")
+	writeTestFile(t, existing, "already filed")
+
+	container := filepath.Join(dir, "mensagem.eml")
+	writeTestFile(t, container, "")
+	document := models.DocumentMetadata{
+		Attachments: []models.EmbeddedFile{
+			{Name: "nota.txt", Data: []byte("Nota fiscal eletronica, valor total R$ 100,00")},
+			{Name: "contrato.txt", Data: []byte("A contratante aceita a clausula")},
+		},
+	}
+	service.ProcessAttachments(container, document)
+
+	pending, err := service.GetPendingReviews()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("pending = %+v, want both attachments", pending)
+	}
+
+	var destinationPath string
+	for _, item := range pending {
+		if item.Filename == "nota.txt" {
+			destinationPath, err = service.ResolveReview(item, "Invoice")
+			if err != nil {
+				t.Fatalf("ResolveReview: %v", err)
+			}
+		} else if err := service.DiscardReview(item); err != nil {
+			t.Fatalf("DiscardReview: %v", err)
+		}
+	}
+
+	if filepath.Base(destinationPath) != "nota (2).txt" {
+		t.Errorf("destination = %s, want nota (2).txt", destinationPath)
+	}
+	if data, err := os.ReadFile(existing); err != nil || string(data) != "already filed" {
+		t.Errorf("existing file was overwritten: %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "output", stagingDirectory)); !os.IsNotExist(err) {
+		t.Errorf("staged entries left behind: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"relatorios/interfaces"
+	"relatorios/models"
+	"sort"
+	"strings"
+)
+
+type RuleCoverageService struct {
+	analyzeService interfaces.AnalyzeService
+}
+
+func NewRuleCoverageService(analyzeService interfaces.AnalyzeService) *RuleCoverageService {
+	return &RuleCoverageService{
+		analyzeService: analyzeService,
+	}
+}
+
+func (s *RuleCoverageService) Analyze(index *models.TextIndex) *models.RuleCoverageReport {
+	coverage := make(map[string]*models.KeywordCoverage)
+	var order []string
+
+	for _, rule := range s.analyzeService.GetRules() {
+		for _, keyword := range rule.Keywords {
+			key := strings.ToLower(keyword)
+			entry, exists := coverage[key]
+			if !exists {
+				entry = &models.KeywordCoverage{
+					Keyword:      keyword,
+					Types:        []string{},
+					WinningTypes: make(map[string]int),
+				}
+				coverage[key] = entry
+				order = append(order, key)
+			}
+
+			if !containsString(entry.Types, rule.Type) {
+				entry.Types = append(entry.Types, rule.Type)
+			}
+		}
+	}
+
+	report := &models.RuleCoverageReport{
+		TotalDocuments: len(index.Documents),
+		Keywords:       make([]models.KeywordCoverage, 0, len(order)),
+		DeadKeywords:   make([]models.KeywordCoverage, 0),
+		SharedKeywords: make([]models.KeywordCoverage, 0),
+	}
+
+	for _, document := range index.Documents {
+		if document.Text == "" {
+			continue
+		}
+
+		matches := s.analyzeService.MatchRules(document)
+
+		bestCount := 0
+		bestType := ""
+		for _, match := range matches {
+			if match.Score() > bestCount {
+				bestCount = match.Score()
+				bestType = match.Rule.Type
+			}
+		}
+
+		tiedMatches := []models.RuleMatch{}
+		for _, match := range matches {
+			if bestCount > 0 && match.Score() == bestCount {
+				tiedMatches = append(tiedMatches, match)
+			}
+		}
+
+		tied := len(tiedMatches) > 1
+		if tied {
+			report.TiedDocuments++
+		}
+
+		hitInDocument := make(map[string]bool)
+		tiedKeywordRules := make(map[string]int)
+
+		for _, match := range matches {
+			for _, keyword := range match.Keywords {
+				key := strings.ToLower(keyword)
+				entry := coverage[key]
+
+				if !hitInDocument[key] {
+					hitInDocument[key] = true
+					entry.Hits++
+				}
+
+				if match.Rule.Type == bestType {
+					entry.WinningTypes[bestType]++
+				}
+			}
+		}
+
+		if tied {
+			for _, match := range tiedMatches {
+				for _, keyword := range match.Keywords {
+					tiedKeywordRules[strings.ToLower(keyword)]++
+				}
+			}
+
+			for key, count := range tiedKeywordRules {
+				if count > 1 {
+					coverage[key].Ties++
+				}
+			}
+		}
+	}
+
+	for _, key := range order {
+		entry := *coverage[key]
+		report.Keywords = append(report.Keywords, entry)
+
+		if entry.Hits == 0 {
+			report.DeadKeywords = append(report.DeadKeywords, entry)
+		}
+
+		if len(entry.Types) > 1 {
+			report.SharedKeywords = append(report.SharedKeywords, entry)
+		}
+	}
+
+	sort.SliceStable(report.Keywords, func(i, j int) bool {
+		return report.Keywords[i].Hits > report.Keywords[j].Hits
+	})
+
+	sort.SliceStable(report.SharedKeywords, func(i, j int) bool {
+		return report.SharedKeywords[i].Ties > report.SharedKeywords[j].Ties
+	})
+
+	return report
+}
+
+func containsString(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"path/filepath"
+	"relatorios/models"
+	"testing"
+)
+
+func TestRuleCoverageServiceAnalyze(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	writeTestFile(t, rulesFile, `[
+		{"type": "Invoice", "keywords": ["nota fiscal", "valor total", "duplicata"]},
+		{"type": "Receipt", "keywords": ["recibo", "Valor Total"]}
+	]`)
+
+	index := &models.TextIndex{Documents: []models.DocumentMetadata{
+		{Filename: "nota.pdf", Text: "Nota fiscal eletronica, valor total R$ 100"},
+		{Filename: "recibo.pdf", Text: "Recibo de pagamento, valor total R$ 10"},
+		{Filename: "empate.pdf", Text: "Documento com valor total"},
+		{Filename: "vazio.pdf"},
+	}}
+
+	report := NewRuleCoverageService(NewAnalyzeDocumentService(rulesFile)).Analyze(index)
+
+	if report.TotalDocuments != 4 || report.TiedDocuments != 1 {
+		t.Errorf("report = %+v, want 4 documents with 1 tie", report)
+	}
+
+	coverage := make(map[string]models.KeywordCoverage)
+	for _, keyword := range report.Keywords {
+		coverage[keyword.Keyword] = keyword
+	}
+
+	if keyword := coverage["valor total"]; keyword.Hits != 3 || keyword.Ties != 1 || len(keyword.Types) != 2 {
+		t.Errorf("valor total = %+v, want 3 hits, 1 tie and 2 types", keyword)
+	}
+	if keyword := coverage["nota fiscal"]; keyword.Hits != 1 || keyword.WinningTypes["Invoice"] != 1 {
+		t.Errorf("nota fiscal = %+v", keyword)
+	}
+	if report.Keywords[0].Keyword != "valor total" {
+		t.Errorf("first keyword = %q, want the most hit", report.Keywords[0].Keyword)
+	}
+
+	if len(report.DeadKeywords) != 1 || report.DeadKeywords[0].Keyword != "duplicata" {
+		t.Errorf("dead keywords = %+v, want duplicata", report.DeadKeywords)
+	}
+	if len(report.SharedKeywords) != 1 || report.SharedKeywords[0].Keyword != "valor total" {
+		t.Errorf("shared keywords = %+v, want valor total", report.SharedKeywords)
+	}
+}
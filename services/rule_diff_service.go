@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"relatorios/interfaces"
+	"relatorios/models"
+	"sort"
+)
+
+const maxSampleFiles = 5
+
+type RuleDiffService struct {
+	analyzeService interfaces.AnalyzeService
+}
+
+func NewRuleDiffService(analyzeService interfaces.AnalyzeService) *RuleDiffService {
+	return &RuleDiffService{
+		analyzeService: analyzeService,
+	}
+}
+
+func (s *RuleDiffService) Compare(index *models.TextIndex, rulesFileA string, rulesFileB string) (*models.RuleDiffReport, error) {
+	analyzerA, err := s.loadAnalyzer(rulesFileA)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzerB, err := s.loadAnalyzer(rulesFileB)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.RuleDiffReport{
+		RulesFileA:     rulesFileA,
+		RulesFileB:     rulesFileB,
+		TotalDocuments: len(index.Documents),
+		Moves:          make([]models.DocumentMove, 0),
+	}
+
+	moves := make(map[string]*models.DocumentMove)
+
+	for _, document := range index.Documents {
+		oldType := analyzerA.ExecuteDocument(document).Classification.DocumentType
+		newType := analyzerB.ExecuteDocument(document).Classification.DocumentType
+
+		if oldType == newType {
+			continue
+		}
+
+		report.ChangedDocuments++
+
+		key := oldType + "\x00" + newType
+		move, exists := moves[key]
+		if !exists {
+			move = &models.DocumentMove{
+				FromType:    oldType,
+				ToType:      newType,
+				SampleFiles: make([]string, 0),
+			}
+			moves[key] = move
+		}
+
+		move.Count++
+		if len(move.SampleFiles) < maxSampleFiles {
+			move.SampleFiles = append(move.SampleFiles, document.Filename)
+		}
+	}
+
+	for _, move := range moves {
+		report.Moves = append(report.Moves, *move)
+	}
+
+	sort.Slice(report.Moves, func(i, j int) bool {
+		if report.Moves[i].Count != report.Moves[j].Count {
+			return report.Moves[i].Count > report.Moves[j].Count
+		}
+		if report.Moves[i].FromType != report.Moves[j].FromType {
+			return report.Moves[i].FromType < report.Moves[j].FromType
+		}
+		return report.Moves[i].ToType < report.Moves[j].ToType
+	})
+
+	return report, nil
+}
+
+func (s *RuleDiffService) loadAnalyzer(rulesFile string) (*AnalyzeDocumentService, error) {
+	if _, err := os.Stat(rulesFile); os.IsNotExist(err) {
+		return nil, fmt.Errorf("rules file not found: %s", rulesFile)
+	}
+
+	rules, err := models.LoadRulesFromJSON(rulesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rules from %s: %w", rulesFile, err)
+	}
+
+	return &AnalyzeDocumentService{
+		rules:                rules,
+		rulesFile:            rulesFile,
+		normalizationOptions: s.analyzeService.GetNormalizationOptions(),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+	"testing"
+)
+
+func TestRuleDiffServiceCompare(t *testing.T) {
+	dir := t.TempDir()
+
+	rulesA := filepath.Join(dir, "a.json")
+	writeTestFile(t, rulesA, `[
+		{"type": "Invoice", "keywords": ["nota fiscal", "valor total"]},
+		{"type": "Contract", "keywords": ["contratante"]}
+	]`)
+
+	rulesB := filepath.Join(dir, "b.json")
+	writeTestFile(t, rulesB, `[
+		{"type": "Invoice", "keywords": ["nota fiscal"]},
+		{"type": "Receipt", "keywords": ["recibo", "valor total"]},
+		{"type": "Contract", "keywords": ["contratante"]}
+	]`)
+
+	index := &models.TextIndex{Documents: []models.DocumentMetadata{
+		{Filename: "nota.pdf", Text: "Nota fiscal eletronica com valor total"},
+		{Filename: "recibo1.pdf", Text: "Recibo de pagamento, valor total R$ 10"},
+		{Filename: "recibo2.pdf", Text: "Recibo de aluguel, valor total R$ 900"},
+		{Filename: "contrato.pdf", Text: "O contratante se compromete"},
+	}}
+
+	service := NewRuleDiffService(NewAnalyzeDocumentService(rulesA))
+	report, err := service.Compare(index, rulesA, rulesB)
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+
+	if report.TotalDocuments != 4 || report.ChangedDocuments != 2 {
+		t.Errorf("report = %+v, want 2 of 4 documents changed", report)
+	}
+	if len(report.Moves) != 1 {
+		t.Fatalf("moves = %+v, want one", report.Moves)
+	}
+
+	move := report.Moves[0]
+	if move.FromType != "Invoice" || move.ToType != "Receipt" || move.Count != 2 ||
+		strings.Join(move.SampleFiles, ",") != "recibo1.pdf,recibo2.pdf" {
+		t.Errorf("move = %+v", move)
+	}
+
+	if _, err := service.Compare(index, rulesA, filepath.Join(dir, "missing.json")); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing rules file error = %v", err)
+	}
+}
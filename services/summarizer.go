@@ -0,0 +1,165 @@
+package services
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxSummarySentences = 2
+	maxSummaryFields    = 3
+	minSentenceLength   = 20
+	maxSentenceLength   = 400
+)
+
+var (
+	datePattern   = regexp.MustCompile(`\b(\d{1,2}/\d{1,2}/\d{2,4}|\d{4}-\d{2}-\d{2})\b`)
+	amountPattern = regexp.MustCompile(`(?i)(r\$|us\$|€|£)\s*\d[\d.,]*\d`)
+	cnpjPattern   = regexp.MustCompile(`\b\d{2}\.\d{3}\.\d{3}/\d{4}-\d{2}\b`)
+)
+
+func summarize(text string, keywords []string) string {
+	summary := strings.Join(keySentences(splitSentences(text), keywords), " ")
+
+	fields := []string{}
+	if dates := findUnique(datePattern, text); len(dates) > 0 {
+		fields = append(fields, "Dates: "+strings.Join(dates, ", "))
+	}
+	if amounts := findUnique(amountPattern, text); len(amounts) > 0 {
+		fields = append(fields, "Amounts: "+strings.Join(amounts, ", "))
+	}
+	if cnpjs := findUnique(cnpjPattern, text); len(cnpjs) > 0 {
+		fields = append(fields, "CNPJ: "+strings.Join(cnpjs, ", "))
+	}
+
+	if len(fields) > 0 {
+		summary += "\n" + strings.Join(fields, " | ")
+	}
+
+	return strings.TrimSpace(summary)
+}
+
+func keySentences(sentences []string, keywords []string) []string {
+	frequencies := make(map[string]int)
+	for _, sentence := range sentences {
+		for _, token := range tokenize(sentence) {
+			if isCandidateTerm(token) && !portugueseStopWords[foldAccents(token)] {
+				frequencies[token]++
+			}
+		}
+	}
+
+	type scoredSentence struct {
+		position int
+		text     string
+		score    float64
+	}
+
+	scored := make([]scoredSentence, 0, len(sentences))
+	for position, sentence := range sentences {
+		tokens := tokenize(sentence)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		score := 0.0
+		for _, token := range tokens {
+			score += float64(frequencies[token])
+		}
+		score /= math.Sqrt(float64(len(tokens)))
+
+		lowerSentence := strings.ToLower(sentence)
+		for _, keyword := range keywords {
+			if strings.Contains(lowerSentence, strings.ToLower(keyword)) {
+				score += 2
+			}
+		}
+
+		scored = append(scored, scoredSentence{position: position, text: sentence, score: score})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	if len(scored) > maxSummarySentences {
+		scored = scored[:maxSummarySentences]
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[i].position < scored[j].position
+	})
+
+	selected := make([]string, 0, len(scored))
+	for _, sentence := range scored {
+		selected = append(selected, sentence.text)
+	}
+
+	return selected
+}
+
+func splitSentences(text string) []string {
+	sentences := []string{}
+	runes := []rune(text)
+	start := 0
+
+	for i, r := range runes {
+		atEnd := i == len(runes)-1
+		boundary := r == '\n'
+		if (r == '.' || r == '!' || r == '?') && (atEnd || unicode.IsSpace(runes[i+1])) {
+			boundary = true
+		}
+
+		if !boundary && !atEnd {
+			continue
+		}
+
+		sentence := strings.Join(strings.Fields(string(runes[start:i+1])), " ")
+		if len(sentence) >= minSentenceLength {
+			sentences = append(sentences, truncateSentence(sentence))
+		}
+		start = i + 1
+	}
+
+	return sentences
+}
+
+func truncateSentence(sentence string) string {
+	runes := []rune(sentence)
+	if len(runes) <= maxSentenceLength {
+		return sentence
+	}
+
+	cut := maxSentenceLength
+	for i := maxSentenceLength; i > maxSentenceLength/2; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+
+	return strings.TrimSpace(string(runes[:cut])) + "..."
+}
+
+func findUnique(pattern *regexp.Regexp, text string) []string {
+	seen := make(map[string]bool)
+	values := []string{}
+
+	for _, match := range pattern.FindAllString(text, -1) {
+		match = strings.Join(strings.Fields(match), " ")
+		if seen[match] {
+			continue
+		}
+
+		seen[match] = true
+		values = append(values, match)
+		if len(values) >= maxSummaryFields {
+			break
+		}
+	}
+
+	return values
+}
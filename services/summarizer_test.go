@@ -0,0 +1,58 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSummarize(t *testing.T) {
+	longPage := strings.Repeat("nota fiscal de serviço emitida para a empresa contratante ", 20) +
+		"no valor de R$ 1.250,00 em 05/03/2024 CNPJ 12.345.678/0001-90"
+
+	tests := []struct {
+		name         string
+		text         string
+		wantContains []string
+		maxSentence  int
+	}{
+		{
+			name:         "long page without sentence breaks",
+			text:         longPage,
+			wantContains: []string{"nota fiscal de serviço", "...", "Dates: 05/03/2024", "Amounts: R$ 1.250,00", "CNPJ: 12.345.678/0001-90"},
+			maxSentence:  maxSentenceLength + len("..."),
+		},
+		{
+			name:         "spreadsheet cells only",
+			text:         "[Sheet: Plan1]\nData | Valor\n01/02/2024 | R$ 10,00",
+			wantContains: []string{"Dates: 01/02/2024", "Amounts: R$ 10,00"},
+		},
+		{
+			name:         "short sentences",
+			text:         "O contrato foi assinado pelas partes. O pagamento vence em 10/04/2024.",
+			wantContains: []string{"O contrato foi assinado pelas partes.", "Dates: 10/04/2024"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			summary := summarize(test.text, []string{"nota fiscal"})
+			for _, want := range test.wantContains {
+				if !strings.Contains(summary, want) {
+					t.Errorf("summary %q does not contain %q", summary, want)
+				}
+			}
+
+			if test.maxSentence > 0 {
+				firstLine := strings.SplitN(summary, "\n", 2)[0]
+				if utf8.RuneCountInString(firstLine) > test.maxSentence {
+					t.Errorf("sentence has %d runes, want at most %d", utf8.RuneCountInString(firstLine), test.maxSentence)
+				}
+			}
+		})
+	}
+
+	if summary := summarize("", nil); summary != "" {
+		t.Errorf("empty text summary = %q", summary)
+	}
+}
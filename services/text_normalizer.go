@@ -0,0 +1,140 @@
+package services
+
+import (
+	"regexp"
+	"relatorios/models"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	moneyToken  = "#valor"
+	numberToken = "#num"
+)
+
+var (
+	moneyPattern  = regexp.MustCompile(`(?i)(r\$|us\$|\$|€|£)\s*\d[\d.,]*`)
+	numberPattern = regexp.MustCompile(`\d+(?:[.,/-]\d+)*`)
+
+	pluralSuffixes = []struct{ suffix, replacement string }{
+		{"oes", "ao"},
+		{"aes", "ao"},
+		{"ais", "al"},
+		{"eis", "el"},
+		{"ois", "ol"},
+		{"res", "r"},
+		{"zes", "z"},
+		{"ns", "m"},
+		{"s", ""},
+	}
+
+	stemSuffixes = []string{
+		"amentos", "imentos", "amento", "imento", "amente", "mente",
+		"idades", "idade", "acoes", "acao", "icao", "ucao",
+		"adoras", "adores", "adora", "ador", "antes", "ante", "entes", "ente",
+		"ancia", "encia", "avel", "ivel", "ismo", "ista",
+		"adas", "ados", "ada", "ado", "idas", "idos", "ida", "ido",
+		"ivas", "ivos", "iva", "ivo", "agem", "eza", "ura",
+		"aram", "eram", "iram", "ando", "endo", "indo", "ava", "ou", "ar", "er", "ir",
+	}
+
+	portugueseStopWords = buildStopWords(`a ao aos aquela aquelas aquele aqueles as ate com como da das de dela delas dele deles
+		do dos e ela elas ele eles em entre era essa essas esse esses esta estas este estes eu foi
+		for ha isso isto ja lhe lhes mais mas me mesmo meu minha muito na nao nas nem no nos nossa
+		nosso num numa o os ou para pela pelas pelo pelos por qual quando que quem se sem ser seu
+		sua suas seus so sob sobre tambem te tem tu um uma umas uns voce voces`)
+
+	accentRemover = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+)
+
+func normalizeTokens(text string, options models.NormalizationOptions) string {
+	text = strings.ToLower(text)
+
+	if options.NormalizeNumbers {
+		text = moneyPattern.ReplaceAllString(text, " "+moneyToken+" ")
+		text = numberPattern.ReplaceAllString(text, " "+numberToken+" ")
+	}
+
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '#' && r != '-'
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		token := strings.Trim(field, "-")
+		if token == "" {
+			continue
+		}
+
+		if strings.HasPrefix(token, "#") {
+			tokens = append(tokens, token)
+			continue
+		}
+
+		token = foldAccents(token)
+
+		if options.RemoveStopWords && portugueseStopWords[token] {
+			continue
+		}
+
+		if options.Stemming {
+			token = stemPortuguese(token)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+func foldAccents(text string) string {
+	folded, _, err := transform.String(accentRemover, text)
+	if err != nil {
+		return text
+	}
+	return folded
+}
+
+func stemPortuguese(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+
+	for _, rule := range pluralSuffixes {
+		if strings.HasSuffix(word, rule.suffix) && len(word)-len(rule.suffix) >= 3 {
+			if rule.suffix == "s" && (strings.HasSuffix(word, "ss") || strings.HasSuffix(word, "us")) {
+				break
+			}
+			word = strings.TrimSuffix(word, rule.suffix) + rule.replacement
+			break
+		}
+	}
+
+	for _, suffix := range stemSuffixes {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 4 {
+			word = strings.TrimSuffix(word, suffix)
+			break
+		}
+	}
+
+	if len(word) > 4 {
+		last := word[len(word)-1]
+		if last == 'a' || last == 'e' || last == 'o' {
+			word = word[:len(word)-1]
+		}
+	}
+
+	return word
+}
+
+func buildStopWords(list string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(list) {
+		words[word] = true
+	}
+	return words
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"path/filepath"
+	"relatorios/models"
+	"strings"
+	"testing"
+)
+
+func TestStemPortugueseWordFamilies(t *testing.T) {
+	tests := []struct {
+		words    []string
+		sameStem bool
+	}{
+		{[]string{"contrato", "contratos", "contratado", "contratante", "contratantes", "contratar", "contratação"}, true},
+		{[]string{"pagamento", "pagamentos"}, true},
+		{[]string{"serviço", "serviços"}, true},
+		{[]string{"cliente", "clientes"}, true},
+		{[]string{"fiscal", "fiscais"}, true},
+		{[]string{"locador", "locadores"}, true},
+		{[]string{"nota", "notas"}, true},
+		{[]string{"nota", "notificação"}, false},
+		{[]string{"serviço", "servidor"}, false},
+		{[]string{"fiscal", "fiscalização"}, false},
+		{[]string{"contrato", "contexto"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.words, "/"), func(t *testing.T) {
+			first := stemPortuguese(foldAccents(test.words[0]))
+			for _, word := range test.words[1:] {
+				stem := stemPortuguese(foldAccents(word))
+				if (stem == first) != test.sameStem {
+					t.Errorf("stem(%s) = %q, stem(%s) = %q; same stem = %v, want %v",
+						test.words[0], first, word, stem, stem == first, test.sameStem)
+				}
+			}
+		})
+	}
+}
+
+func TestStemPortugueseKeepsShortAndLatinWords(t *testing.T) {
+	for word, want := range map[string]string{"mes": "mes", "pao": "pao", "onus": "onus", "status": "status", "classe": "class"} {
+		if got := stemPortuguese(word); got != want {
+			t.Errorf("stemPortuguese(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestNormalizeTokens(t *testing.T) {
+	text := "O contrato da empresa é para os clientes: R$ 1.500,00 em 12/03/2024"
+
+	tests := []struct {
+		name    string
+		options models.NormalizationOptions
+		want    string
+	}{
+		{"stop words", models.NormalizationOptions{RemoveStopWords: true}, "contrato empresa clientes r 1 500 00 12 03 2024"},
+		{"numbers", models.NormalizationOptions{NormalizeNumbers: true}, "o contrato da empresa e para os clientes #valor em #num"},
+		{"stemming", models.NormalizationOptions{Stemming: true}, "o contrat da empres e para os client r 1 500 00 em 12 03 2024"},
+		{"everything", models.NormalizationOptions{Stemming: true, RemoveStopWords: true, NormalizeNumbers: true}, "contrat empres client #valor #num"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := normalizeTokens(text, test.options); got != test.want {
+				t.Errorf("normalizeTokens() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMatchKeywordsWithNormalizationRequiresWholeTokens(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	writeTestFile(t, rulesFile, `[{"type": "Contract", "keywords": ["contrato", "ato", "fiscal", "de serviço"]}]`)
+
+	service := NewAnalyzeDocumentService(rulesFile)
+	text := "Os contratos de serviços e a fiscalização do ato"
+
+	if got := strings.Join(service.MatchKeywords(text, "Contract"), ","); got != "contrato,ato,fiscal,de serviço" {
+		t.Errorf("keywords without normalization = %q, want every substring match", got)
+	}
+
+	service.SetNormalizationOptions(models.NormalizationOptions{Stemming: true, RemoveStopWords: true})
+	if got := strings.Join(service.MatchKeywords(text, "Contract"), ","); got != "contrato,ato,de serviço" {
+		t.Errorf("keywords = %q, want contrato, ato and de serviço", got)
+	}
+
+	if got := service.MatchKeywords("Um mandato sem contratação", "Contract"); strings.Join(got, ",") != "contrato" {
+		t.Errorf("keywords = %q, want only contrato (ato is part of mandato)", got)
+	}
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+)
+
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, "-")
+		if field != "" {
+			tokens = append(tokens, field)
+		}
+	}
+
+	return tokens
+}
+
+func isCandidateTerm(token string) bool {
+	if len([]rune(token)) < 3 {
+		return false
+	}
+
+	for _, r := range token {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,23 +2,45 @@ package ui
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"relatorios/models"
 	"relatorios/services"
+	"sort"
+	"strconv"
 	"strings"
 )
 
+const infoDateLayout = "2006-01-02 15:04"
+
 type ConsoleInterface struct {
 	processingService *services.DocumentProcessingService
+	ruleDiffService   *services.RuleDiffService
+	keywordService    *services.KeywordSuggestionService
+	feedbackService   *services.FeedbackService
+	coverageService   *services.RuleCoverageService
+	normalizationFile string
 	reader            *bufio.Reader
 	fileBrowser       *FileBrowser
 }
 
-func NewConsoleInterface(processingService *services.DocumentProcessingService) *ConsoleInterface {
+func NewConsoleInterface(
+	processingService *services.DocumentProcessingService,
+	ruleDiffService *services.RuleDiffService,
+	keywordService *services.KeywordSuggestionService,
+	feedbackService *services.FeedbackService,
+	coverageService *services.RuleCoverageService,
+	normalizationFile string,
+) *ConsoleInterface {
 	consoleInterface := &ConsoleInterface{
 		processingService: processingService,
+		ruleDiffService:   ruleDiffService,
+		keywordService:    keywordService,
+		feedbackService:   feedbackService,
+		coverageService:   coverageService,
+		normalizationFile: normalizationFile,
 		reader:            bufio.NewReader(os.Stdin),
 	}
 
@@ -35,16 +57,8 @@ func (ci *ConsoleInterface) ReadLine() (string, error) {
 	return strings.TrimSpace(text), nil
 }
 
-func (ci *ConsoleInterface) IsFormatSupported(filename string) bool {
-	supportedFormats := ci.processingService.GetSupportedFormats()
-	ext := strings.ToLower(filepath.Ext(filename))
-
-	for _, format := range supportedFormats {
-		if ext == format {
-			return true
-		}
-	}
-	return false
+func (ci *ConsoleInterface) IsFormatSupported(filePath string) bool {
+	return ci.processingService.IsFormatSupported(filePath)
 }
 
 func (ci *ConsoleInterface) Start(initialPath string) error {
@@ -91,10 +105,17 @@ func (ci *ConsoleInterface) showMainMenu() {
 	fmt.Println("3. Show current classification rules")
 	fmt.Println("4. Reload classification rules")
 	fmt.Println("5. Select rules file")
-	fmt.Println("6. Exit")
+	fmt.Println("6. Compare rules files (impact report)")
+	fmt.Println("7. Suggest keywords from sample folders")
+	fmt.Printf("8. Review pending documents (%d)\n", ci.countPendingReviews())
+	fmt.Println("9. Corrections report and retraining")
+	fmt.Println("10. Select or train classifier")
+	fmt.Println("11. Text normalization settings")
+	fmt.Println("12. Rule coverage report")
+	fmt.Println("13. Exit")
 	fmt.Println()
 
-	fmt.Print("Enter your choice (1-6): ")
+	fmt.Print("Enter your choice (1-13): ")
 	choice, _ := ci.ReadLine()
 	choice = strings.TrimSpace(choice)
 
@@ -110,6 +131,20 @@ func (ci *ConsoleInterface) showMainMenu() {
 	case "5":
 		ci.selectRulesFile()
 	case "6":
+		ci.compareRulesFiles()
+	case "7":
+		ci.suggestKeywords()
+	case "8":
+		ci.reviewPendingDocuments()
+	case "9":
+		ci.showCorrectionReport()
+	case "10":
+		ci.manageClassifiers()
+	case "11":
+		ci.configureNormalization()
+	case "12":
+		ci.showRuleCoverage()
+	case "13":
 		fmt.Println("Exiting program...")
 		os.Exit(0)
 	default:
@@ -130,7 +165,23 @@ func (ci *ConsoleInterface) showClassificationRules() {
 
 	for i, rule := range rules {
 		fmt.Printf("%d. Type: %s\n", i+1, rule.Type)
-		fmt.Printf("   Keywords: %s\n\n", strings.Join(rule.Keywords, ", "))
+		fmt.Printf("   Keywords: %s\n", strings.Join(rule.Keywords, ", "))
+		if len(rule.Languages) > 0 {
+			fmt.Printf("   Languages: %s\n", strings.Join(rule.Languages, ", "))
+		}
+		if len(rule.Pages) > 0 {
+			fmt.Printf("   Pages: %s\n", formatPages(rule.Pages))
+		}
+		for _, section := range sortedKeys(rule.SectionKeywords) {
+			fmt.Printf("   Section %s: %s\n", section, strings.Join(rule.SectionKeywords[section], ", "))
+		}
+		for _, field := range sortedKeys(rule.Fields) {
+			fmt.Printf("   Field %s: %s\n", field, rule.Fields[field])
+		}
+		if rule.Structure != nil {
+			fmt.Printf("   Structure: %s\n", formatStructureRule(rule.Structure))
+		}
+		fmt.Println()
 	}
 
 	fmt.Print("\nPress Enter to return to main menu...")
@@ -267,21 +318,91 @@ func (ci *ConsoleInterface) selectDirectory() {
 func (ci *ConsoleInterface) handleSingleFile(filePath string) error {
 	fmt.Printf("\nProcessing file: %s\n", filePath)
 
-	document, destinationPath, err := ci.processingService.ProcessSingleFile(filePath)
+	format := ci.processingService.DetectFormat(filePath)
+	if ci.processingService.IsArchiveFormat(format) {
+		fmt.Println("\n===== Archive Result =====")
+		for _, entryResult := range ci.processingService.ProcessArchive(filePath, format) {
+			ci.printFileResult(entryResult)
+		}
+		fmt.Printf("\nDocuments organized at: %s\n", ci.processingService.GetOutputDirectory())
+		return nil
+	}
+
+	document, destinationPath, err := ci.processingService.ProcessSingleFile(filePath, format)
 	if err != nil {
 		return err
 	}
 
 	fmt.Println("\n===== Classification Result =====")
 	fmt.Printf("File: %s\n", document.Filename)
+	if document.Language != "" {
+		fmt.Printf("Language: %s\n", document.Language)
+	}
 	if document.Classification != nil {
 		fmt.Printf("Document type: %s\n", document.Classification.DocumentType)
-		fmt.Printf("Keywords: %s\n", strings.Join(document.Classification.Keywords, ", "))
+		fmt.Printf("Confidence: %.0f%%\n", document.Classification.Confidence*100)
+		if document.Classification.NearestExample != "" {
+			fmt.Printf("Nearest example: %s (similarity %.2f)\n",
+				document.Classification.NearestExample,
+				document.Classification.Similarity)
+		} else {
+			fmt.Printf("Keywords: %s\n", formatKeywords(*document.Classification))
+		}
 	} else {
 		fmt.Println("Could not classify document")
 	}
+
+	if document.Info != nil {
+		ci.printDocumentInfo(document.Info)
+	}
+
+	if len(document.Metadata) > 0 {
+		keys := make([]string, 0, len(document.Metadata))
+		for key := range document.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Println("\n--- Metadata ---")
+		for _, key := range keys {
+			fmt.Printf("%s: %s\n", key, document.Metadata[key])
+		}
+	}
+
+	if len(document.Warnings) > 0 {
+		fmt.Println("\n--- Warnings ---")
+		for _, warning := range document.Warnings {
+			if warning.Page > 0 {
+				fmt.Printf("[%s, page %d] %s\n", warning.Source, warning.Page, warning.Message)
+			} else {
+				fmt.Printf("[%s] %s\n", warning.Source, warning.Message)
+			}
+		}
+	}
+
+	if document.Summary != "" {
+		fmt.Println("\n--- Summary ---")
+		fmt.Println(document.Summary)
+	}
+
+	if attachmentResults := ci.processingService.ProcessAttachments(filePath, document); len(attachmentResults) > 0 {
+		fmt.Println("\n--- Attachments ---")
+		for _, attachmentResult := range attachmentResults {
+			ci.printFileResult(attachmentResult)
+		}
+	}
+
+	if document.Classification != nil && document.Classification.NeedsReview {
+		fmt.Println("\nLow confidence: document added to the review queue")
+		return nil
+	}
+
 	fmt.Printf("\nFile organized at: %s\n", destinationPath)
 
+	if document.Classification != nil {
+		ci.askForCorrection(document, destinationPath)
+	}
+
 	return nil
 }
 
@@ -296,20 +417,791 @@ func (ci *ConsoleInterface) handleDirectory(dirPath string) error {
 	fmt.Println("\n===== Processing Result =====")
 	fmt.Printf("Total files processed: %d\n", result.ProcessedCount)
 	fmt.Printf("Total failures: %d\n", result.FailedCount)
+	fmt.Printf("Pending review: %d\n", result.PendingReviewCount)
+	fmt.Printf("Skipped: %d\n", result.SkippedCount)
 
 	for _, fileResult := range result.Results {
-		if fileResult.Success {
-			fmt.Printf("\n✓ %s → %s\n",
-				fileResult.Filename,
-				fileResult.DocumentType)
-		} else {
-			fmt.Printf("\n✗ %s → FAILED: %s\n",
-				fileResult.Filename,
-				fileResult.Error)
-		}
+		ci.printFileResult(fileResult)
 	}
 
 	fmt.Printf("\nDocuments organized at: %s\n", ci.processingService.GetOutputDirectory())
+	if result.ReportPath != "" {
+		fmt.Printf("Report written to: %s\n", result.ReportPath)
+	}
 
 	return nil
 }
+
+func (ci *ConsoleInterface) printDocumentInfo(info *models.DocumentInfo) {
+	fmt.Println("\n--- Document Info ---")
+	fmt.Printf("Path: %s\n", info.Path)
+	fmt.Printf("Size: %d bytes\n", info.Size)
+	fmt.Printf("SHA-256: %s\n", info.SHA256)
+	if info.MimeType != "" {
+		fmt.Printf("MIME type: %s\n", info.MimeType)
+	}
+	if info.Title != "" {
+		fmt.Printf("Title: %s\n", info.Title)
+	}
+	if info.Author != "" {
+		fmt.Printf("Author: %s\n", info.Author)
+	}
+	if info.PageCount > 0 {
+		fmt.Printf("Pages: %d\n", info.PageCount)
+	}
+	if info.SheetCount > 0 {
+		fmt.Printf("Sheets: %d\n", info.SheetCount)
+	}
+	if info.SlideCount > 0 {
+		fmt.Printf("Slides: %d\n", info.SlideCount)
+	}
+	if info.Created != nil {
+		fmt.Printf("Created: %s\n", info.Created.Format(infoDateLayout))
+	}
+	if info.Modified != nil {
+		fmt.Printf("Modified: %s\n", info.Modified.Format(infoDateLayout))
+	}
+	if info.DateTaken != nil {
+		fmt.Printf("Date taken: %s\n", info.DateTaken.Format(infoDateLayout))
+	}
+	fmt.Printf("Extracted by %s in %d ms\n", info.Extractor, info.ExtractionMs)
+}
+
+func (ci *ConsoleInterface) printFileResult(fileResult models.FileProcessingResult) {
+	if fileResult.Skipped {
+		fmt.Printf("\n- %s → SKIPPED\n", fileResult.Filename)
+	} else if fileResult.PendingReview {
+		fmt.Printf("\n? %s → %s (%.0f%%) PENDING REVIEW\n",
+			fileResult.Filename,
+			fileResult.DocumentType,
+			fileResult.Confidence*100)
+	} else if fileResult.Success {
+		fmt.Printf("\n✓ %s → %s\n",
+			fileResult.Filename,
+			fileResult.DocumentType)
+		if fileResult.Summary != "" {
+			fmt.Printf("  %s\n", strings.ReplaceAll(fileResult.Summary, "\n", "\n  "))
+		}
+	} else {
+		fmt.Printf("\n✗ %s → FAILED: %s\n",
+			fileResult.Filename,
+			fileResult.Error)
+	}
+
+	if fileResult.Warning != "" {
+		fmt.Printf("  ! %s\n", fileResult.Warning)
+	}
+}
+
+func (ci *ConsoleInterface) compareRulesFiles() {
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("=== Rule Change Impact Report ===")
+
+	index, err := ci.selectTextIndex()
+	if err != nil {
+		fmt.Printf("\nError loading documents: %v\n", err)
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+		ci.showMainMenu()
+		return
+	}
+
+	if index == nil {
+		ci.showMainMenu()
+		return
+	}
+
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("=== Select Rules File To Compare ===")
+	fmt.Println("Navigate to the JSON rules file you want to compare with the current one.")
+	fmt.Print("\nPress Enter to continue...")
+	ci.ReadLine()
+
+	startDir, err := os.Getwd()
+	if err != nil {
+		startDir = "/"
+	}
+
+	rulesFileB, err := ci.fileBrowser.BrowseFilesWithFilter(startDir, true, []string{".json"})
+	if err != nil {
+		fmt.Printf("\nError browsing files: %v\n", err)
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+		ci.showMainMenu()
+		return
+	}
+
+	if rulesFileB == "" {
+		ci.showMainMenu()
+		return
+	}
+
+	rulesFileA := ci.processingService.GetAnalyzeService().GetRulesFilePath()
+
+	report, err := ci.ruleDiffService.Compare(index, rulesFileA, rulesFileB)
+	if err != nil {
+		fmt.Printf("\nError comparing rules: %v\n", err)
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+		ci.showMainMenu()
+		return
+	}
+
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("===== Rule Change Impact Report =====")
+	fmt.Printf("Current rules (A): %s\n", report.RulesFileA)
+	fmt.Printf("New rules (B):     %s\n", report.RulesFileB)
+	fmt.Printf("\nDocuments classified: %d\n", report.TotalDocuments)
+	fmt.Printf("Documents that would change type: %d\n", report.ChangedDocuments)
+
+	if len(report.Moves) == 0 {
+		fmt.Println("\nNo document would change type.")
+	}
+
+	for _, move := range report.Moves {
+		fmt.Printf("\n%s → %s: %d document(s)\n", move.FromType, move.ToType, move.Count)
+		for _, sample := range move.SampleFiles {
+			fmt.Printf("   - %s\n", sample)
+		}
+	}
+
+	fmt.Print("\nPress Enter to return to main menu...")
+	ci.ReadLine()
+	ci.showMainMenu()
+}
+
+func (ci *ConsoleInterface) selectTextIndex() (*models.TextIndex, error) {
+	fmt.Println("\nSelect the documents to classify:")
+	fmt.Println("1. A folder of documents")
+	fmt.Println("2. A saved text index (.json)")
+	fmt.Print("\nEnter your choice (1-2): ")
+
+	choice, _ := ci.ReadLine()
+
+	startDir, err := os.Getwd()
+	if err != nil {
+		startDir = "/"
+	}
+
+	switch strings.TrimSpace(choice) {
+	case "1":
+		dirPath, err := ci.fileBrowser.BrowseFiles(startDir, false)
+		if err != nil || dirPath == "" {
+			return nil, err
+		}
+
+		fmt.Printf("\nExtracting text from: %s\n", dirPath)
+		index, err := ci.processingService.BuildTextIndex(dirPath)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Print("\nSave the extracted text index for reuse? (y/N): ")
+		answer, _ := ci.ReadLine()
+		if strings.EqualFold(strings.TrimSpace(answer), "y") {
+			indexPath := filepath.Join(ci.processingService.GetOutputDirectory(), "text_index.json")
+			if err := models.SaveTextIndexToJSON(indexPath, index); err != nil {
+				fmt.Printf("Could not save text index: %v\n", err)
+			} else {
+				fmt.Printf("Text index saved at: %s\n", indexPath)
+			}
+		}
+
+		return index, nil
+	case "2":
+		indexPath, err := ci.fileBrowser.BrowseFilesWithFilter(startDir, true, []string{".json"})
+		if err != nil || indexPath == "" {
+			return nil, err
+		}
+
+		return models.LoadTextIndexFromJSON(indexPath)
+	}
+
+	return nil, nil
+}
+
+func (ci *ConsoleInterface) suggestKeywords() {
+	startDir, err := os.Getwd()
+	if err != nil {
+		startDir = "/"
+	}
+
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("=== Suggest Keywords From Samples ===")
+	fmt.Println("Select a folder containing one subfolder per document type,")
+	fmt.Println("each holding sample documents already sorted by hand.")
+	fmt.Print("\nPress Enter to continue...")
+	ci.ReadLine()
+
+	samplesDir, err := ci.fileBrowser.BrowseFiles(startDir, false)
+	if err != nil {
+		fmt.Printf("\nError browsing directories: %v\n", err)
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+		ci.showMainMenu()
+		return
+	}
+
+	if samplesDir == "" {
+		ci.showMainMenu()
+		return
+	}
+
+	fmt.Printf("\nAnalyzing samples in: %s\n", samplesDir)
+
+	analyzeService := ci.processingService.GetAnalyzeService()
+	rules := analyzeService.GetRules()
+
+	suggestions, err := ci.keywordService.SuggestKeywords(samplesDir, rules)
+	if err != nil {
+		fmt.Printf("\nError suggesting keywords: %v\n", err)
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+		ci.showMainMenu()
+		return
+	}
+
+	fmt.Println("\n===== Suggested Keywords =====")
+	for _, typeSuggestions := range suggestions {
+		fmt.Printf("\n%s (%d samples)\n", typeSuggestions.Type, typeSuggestions.SampleCount)
+
+		if len(typeSuggestions.Suggestions) == 0 {
+			fmt.Println("   No distinctive terms found")
+			continue
+		}
+
+		for _, suggestion := range typeSuggestions.Suggestions {
+			fmt.Printf("   + %-30s precision %3.0f%%  coverage %3.0f%%\n",
+				suggestion.Keyword,
+				suggestion.Precision*100,
+				suggestion.Coverage*100)
+		}
+	}
+
+	fmt.Print("\nWrite a draft rules file with these additions? (y/N): ")
+	answer, _ := ci.ReadLine()
+	if strings.EqualFold(strings.TrimSpace(answer), "y") {
+		draftPath := filepath.Join(ci.processingService.GetOutputDirectory(), "draft_rules.json")
+		draft := ci.keywordService.BuildDraftRules(rules, suggestions)
+
+		if err := models.CompileFieldPatterns(draft); err != nil {
+			fmt.Printf("\nError in draft rules: %v\n", err)
+		} else if err := models.SaveRulesToJSON(draftPath, draft); err != nil {
+			fmt.Printf("\nCould not write draft rules: %v\n", err)
+		} else {
+			fmt.Printf("\nDraft rules written to: %s\n", draftPath)
+			fmt.Println("Review it, then load it with \"Select rules file\".")
+		}
+	}
+
+	fmt.Print("\nPress Enter to return to main menu...")
+	ci.ReadLine()
+	ci.showMainMenu()
+}
+
+func (ci *ConsoleInterface) countPendingReviews() int {
+	items, err := ci.processingService.GetPendingReviews()
+	if err != nil {
+		return 0
+	}
+	return len(items)
+}
+
+func (ci *ConsoleInterface) reviewPendingDocuments() {
+	for {
+		fmt.Print("\033[H\033[2J")
+		fmt.Println("=== Review Pending Documents ===")
+
+		items, err := ci.processingService.GetPendingReviews()
+		if err != nil {
+			fmt.Printf("\nError loading review queue: %v\n", err)
+			fmt.Print("\nPress Enter to return to main menu...")
+			ci.ReadLine()
+			ci.showMainMenu()
+			return
+		}
+
+		if len(items) == 0 {
+			fmt.Println("\nNo documents pending review.")
+			fmt.Print("\nPress Enter to return to main menu...")
+			ci.ReadLine()
+			ci.showMainMenu()
+			return
+		}
+
+		fmt.Println("[0] Back to main menu")
+		fmt.Println("-------------------------------------")
+		for i, item := range items {
+			fmt.Printf("[%d] %s → %s (%.0f%%)\n",
+				i+1,
+				item.Filename,
+				item.Classification.DocumentType,
+				item.Classification.Confidence*100)
+		}
+
+		fmt.Print("\nSelect a document: ")
+		input, _ := ci.ReadLine()
+
+		var index int
+		if _, err := fmt.Sscanf(strings.TrimSpace(input), "%d", &index); err != nil {
+			continue
+		}
+
+		if index == 0 {
+			ci.showMainMenu()
+			return
+		}
+
+		if index >= 1 && index <= len(items) {
+			ci.reviewDocument(items[index-1])
+		}
+	}
+}
+
+func (ci *ConsoleInterface) reviewDocument(item models.ReviewItem) {
+	rules := ci.processingService.GetAnalyzeService().GetRules()
+
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("=== Review Document ===")
+	fmt.Printf("File: %s\n", item.Filename)
+	fmt.Printf("Source: %s\n", item.SourcePath)
+	fmt.Printf("Suggested type: %s (%.0f%%)\n", item.Classification.DocumentType, item.Classification.Confidence*100)
+	fmt.Printf("Keywords: %s\n", formatKeywords(item.Classification))
+
+	fmt.Println("\n--- Text preview ---")
+	fmt.Println(item.TextPreview)
+	fmt.Println("--------------------")
+
+	fmt.Println("\nFile as:")
+	ci.printTypeChoices(rules, item.Classification)
+	fmt.Println("[D] Discard from queue")
+	fmt.Println("[S] Skip")
+
+	fmt.Print("\nSelect an option: ")
+	input, _ := ci.ReadLine()
+	input = strings.ToUpper(strings.TrimSpace(input))
+
+	switch input {
+	case "S", "":
+		return
+	case "D":
+		if err := ci.processingService.DiscardReview(item); err != nil {
+			fmt.Printf("\nError discarding document: %v\n", err)
+			fmt.Print("Press Enter to continue...")
+			ci.ReadLine()
+		}
+		return
+	}
+
+	documentType := ci.parseTypeChoice(input, rules)
+	if documentType == "" {
+		return
+	}
+
+	destinationPath, err := ci.processingService.ResolveReview(item, documentType)
+	if err != nil {
+		fmt.Printf("\nError filing document: %v\n", err)
+	} else {
+		fmt.Printf("\nFile organized at: %s\n", destinationPath)
+	}
+
+	fmt.Print("Press Enter to continue...")
+	ci.ReadLine()
+}
+
+func (ci *ConsoleInterface) printTypeChoices(rules []models.DocumentRule, classification models.DocumentClassification) {
+	scores := make(map[string]int)
+	for _, candidate := range classification.Candidates {
+		scores[candidate.DocumentType] = candidate.Score
+	}
+
+	for i, rule := range rules {
+		if score, ok := scores[rule.Type]; ok {
+			fmt.Printf("[%d] %s (score %d)\n", i+1, rule.Type, score)
+		} else {
+			fmt.Printf("[%d] %s\n", i+1, rule.Type)
+		}
+	}
+	fmt.Println("[N] New type")
+}
+
+func (ci *ConsoleInterface) parseTypeChoice(input string, rules []models.DocumentRule) string {
+	if input == "N" {
+		fmt.Print("\nEnter the document type: ")
+		documentType, _ := ci.ReadLine()
+		documentType = strings.TrimSpace(documentType)
+		if err := models.ValidateDocumentType(documentType); err != nil {
+			fmt.Printf("\n%v\n", err)
+			return ""
+		}
+		return documentType
+	}
+
+	var index int
+	if _, err := fmt.Sscanf(input, "%d", &index); err == nil && index >= 1 && index <= len(rules) {
+		return rules[index-1].Type
+	}
+
+	return ""
+}
+
+func (ci *ConsoleInterface) askForCorrection(document models.DocumentMetadata, destinationPath string) {
+	fmt.Print("\nIs this classification correct? (Y/n): ")
+	answer, _ := ci.ReadLine()
+	if !strings.EqualFold(strings.TrimSpace(answer), "n") {
+		return
+	}
+
+	rules := ci.processingService.GetAnalyzeService().GetRules()
+
+	fmt.Println("\nCorrect type:")
+	ci.printTypeChoices(rules, *document.Classification)
+
+	fmt.Print("\nSelect an option: ")
+	input, _ := ci.ReadLine()
+
+	documentType := ci.parseTypeChoice(strings.ToUpper(strings.TrimSpace(input)), rules)
+	if documentType == "" || documentType == document.Classification.DocumentType {
+		return
+	}
+
+	correctedPath, err := ci.processingService.CorrectClassification(document, destinationPath, documentType)
+	if err != nil {
+		fmt.Printf("\nError applying correction: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\nCorrection recorded. File moved to: %s\n", correctedPath)
+}
+
+func (ci *ConsoleInterface) showCorrectionReport() {
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("=== Corrections Report ===")
+
+	report, err := ci.feedbackService.BuildCorrectionReport()
+	if err != nil {
+		fmt.Printf("\nError building report: %v\n", err)
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+		ci.showMainMenu()
+		return
+	}
+
+	fmt.Printf("Labeled examples: %d\n", report.TotalExamples)
+	fmt.Printf("Corrections: %d\n", report.Corrections)
+	if report.UnreadableExamples > 0 {
+		fmt.Printf("Examples no longer readable: %d\n", report.UnreadableExamples)
+	}
+
+	if len(report.Confusions) > 0 {
+		fmt.Println("\n--- Most frequent mistakes ---")
+		for _, confusion := range report.Confusions {
+			fmt.Printf("%s → %s: %d\n", confusion.FromType, confusion.ToType, confusion.Count)
+		}
+	}
+
+	if len(report.MisleadingKeywords) > 0 {
+		fmt.Println("\n--- Keywords behind the mistakes ---")
+		for _, keyword := range report.MisleadingKeywords {
+			fmt.Printf("%-25s (%s) in %d mistake(s): %s\n",
+				keyword.Keyword,
+				keyword.DocumentType,
+				keyword.Count,
+				strings.Join(keyword.SampleFiles, ", "))
+		}
+	}
+
+	if ci.processingService.IsClassifierTrainable() {
+		fmt.Print("\nRetrain the classifier with all labeled examples? (y/N): ")
+		answer, _ := ci.ReadLine()
+		if strings.EqualFold(strings.TrimSpace(answer), "y") {
+			count, err := ci.processingService.RetrainClassifier()
+			if err != nil {
+				fmt.Printf("\nError retraining classifier: %v\n", err)
+			} else {
+				fmt.Printf("\nClassifier retrained with %d examples\n", count)
+			}
+		}
+	}
+
+	fmt.Print("\nPress Enter to return to main menu...")
+	ci.ReadLine()
+	ci.showMainMenu()
+}
+
+func (ci *ConsoleInterface) manageClassifiers() {
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("=== Classifiers ===")
+
+	available := ci.processingService.GetClassifiers()
+	active := ci.processingService.GetClassifierName()
+
+	for i, classifier := range available {
+		marker := " "
+		if classifier.GetClassifierName() == active {
+			marker = "*"
+		}
+		fmt.Printf("[%d] %s %s\n", i+1, marker, classifier.GetClassifierName())
+	}
+
+	fmt.Println("[T] Train the active classifier from sample folders")
+	fmt.Println("[0] Back to main menu")
+
+	fmt.Print("\nSelect an option: ")
+	input, _ := ci.ReadLine()
+	input = strings.ToUpper(strings.TrimSpace(input))
+
+	if input == "T" {
+		ci.trainClassifierFromFolders()
+		return
+	}
+
+	var index int
+	if _, err := fmt.Sscanf(input, "%d", &index); err == nil && index >= 1 && index <= len(available) {
+		name := available[index-1].GetClassifierName()
+		if err := ci.processingService.SelectClassifier(name); err != nil {
+			fmt.Printf("\nError selecting classifier: %v\n", err)
+		} else {
+			fmt.Printf("\nActive classifier: %s\n", name)
+		}
+
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+	}
+
+	ci.showMainMenu()
+}
+
+func (ci *ConsoleInterface) trainClassifierFromFolders() {
+	startDir, err := os.Getwd()
+	if err != nil {
+		startDir = "/"
+	}
+
+	if !ci.processingService.IsClassifierTrainable() {
+		fmt.Printf("\n%s cannot be trained from examples.\n", ci.processingService.GetClassifierName())
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+		ci.showMainMenu()
+		return
+	}
+
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("=== Train Classifier From Folders ===")
+	fmt.Println("Select a folder containing one subfolder per document type,")
+	fmt.Println("each holding exemplar documents of that type.")
+	fmt.Print("\nPress Enter to continue...")
+	ci.ReadLine()
+
+	samplesDir, err := ci.fileBrowser.BrowseFiles(startDir, false)
+	if err != nil {
+		fmt.Printf("\nError browsing directories: %v\n", err)
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+		ci.showMainMenu()
+		return
+	}
+
+	if samplesDir == "" {
+		ci.showMainMenu()
+		return
+	}
+
+	fmt.Printf("\nTraining with samples in: %s\n", samplesDir)
+
+	count, err := ci.processingService.TrainClassifierFromFolders(samplesDir)
+	if err != nil {
+		fmt.Printf("\nError training classifier: %v\n", err)
+	} else {
+		fmt.Printf("\nClassifier trained with %d exemplar documents\n", count)
+	}
+
+	fmt.Print("\nPress Enter to return to main menu...")
+	ci.ReadLine()
+	ci.showMainMenu()
+}
+
+func (ci *ConsoleInterface) configureNormalization() {
+	analyzeService := ci.processingService.GetAnalyzeService()
+
+	for {
+		options := analyzeService.GetNormalizationOptions()
+
+		fmt.Print("\033[H\033[2J")
+		fmt.Println("=== Text Normalization Settings ===")
+		fmt.Println("Keywords are normalized the same way as the document text.")
+		fmt.Println()
+		fmt.Printf("[1] Portuguese stemming (contratos, contratado → contrat): %s\n", onOff(options.Stemming))
+		fmt.Printf("[2] Stop-word removal (de, para, com...): %s\n", onOff(options.RemoveStopWords))
+		fmt.Printf("[3] Number and currency normalization (R$ 1.234,56 → #valor): %s\n", onOff(options.NormalizeNumbers))
+		fmt.Println("[0] Save and return to main menu")
+
+		fmt.Print("\nToggle an option: ")
+		input, _ := ci.ReadLine()
+
+		switch strings.TrimSpace(input) {
+		case "1":
+			options.Stemming = !options.Stemming
+		case "2":
+			options.RemoveStopWords = !options.RemoveStopWords
+		case "3":
+			options.NormalizeNumbers = !options.NormalizeNumbers
+		case "0":
+			if err := models.SaveNormalizationOptionsToJSON(ci.normalizationFile, options); err != nil {
+				fmt.Printf("\nError saving settings: %v\n", err)
+				fmt.Print("\nPress Enter to return to main menu...")
+				ci.ReadLine()
+			}
+			ci.showMainMenu()
+			return
+		default:
+			continue
+		}
+
+		analyzeService.SetNormalizationOptions(options)
+	}
+}
+
+func formatKeywords(classification models.DocumentClassification) string {
+	keywords := make([]string, 0, len(classification.Keywords))
+
+	for _, keyword := range classification.Keywords {
+		if pages := classification.KeywordPages[keyword]; len(pages) > 0 {
+			keyword = fmt.Sprintf("%s (p. %s)", keyword, formatPages(pages))
+		}
+		keywords = append(keywords, keyword)
+	}
+
+	return strings.Join(keywords, ", ")
+}
+
+func formatPages(pages []int) string {
+	numbers := make([]string, len(pages))
+	for i, page := range pages {
+		numbers[i] = strconv.Itoa(page)
+	}
+	return strings.Join(numbers, ", ")
+}
+
+func formatStructureRule(structure *models.StructureRule) string {
+	var parts []string
+
+	if len(structure.SheetNames) > 0 {
+		parts = append(parts, "sheets "+strings.Join(structure.SheetNames, ", "))
+	}
+	if len(structure.Headers) > 0 {
+		parts = append(parts, "headers "+strings.Join(structure.Headers, ", "))
+	}
+	if structure.MinColumns > 0 {
+		parts = append(parts, fmt.Sprintf("min %d columns", structure.MinColumns))
+	}
+	if structure.MaxColumns > 0 {
+		parts = append(parts, fmt.Sprintf("max %d columns", structure.MaxColumns))
+	}
+	if structure.HasTotalsRow != nil {
+		if *structure.HasTotalsRow {
+			parts = append(parts, "totals row")
+		} else {
+			parts = append(parts, "no totals row")
+		}
+	}
+
+	if len(parts) == 0 {
+		return "any"
+	}
+	return strings.Join(parts, "; ")
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "ON"
+	}
+	return "OFF"
+}
+
+func (ci *ConsoleInterface) showRuleCoverage() {
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("=== Rule Coverage Report ===")
+
+	index, err := ci.selectTextIndex()
+	if err != nil {
+		fmt.Printf("\nError loading documents: %v\n", err)
+		fmt.Print("\nPress Enter to return to main menu...")
+		ci.ReadLine()
+		ci.showMainMenu()
+		return
+	}
+
+	if index == nil {
+		ci.showMainMenu()
+		return
+	}
+
+	report := ci.coverageService.Analyze(index)
+
+	fmt.Print("\033[H\033[2J")
+	fmt.Println("===== Rule Coverage Report =====")
+	fmt.Printf("Documents analyzed: %d\n", report.TotalDocuments)
+	fmt.Printf("Documents with tied rules: %d\n", report.TiedDocuments)
+
+	fmt.Println("\n--- Keyword hits ---")
+	for _, keyword := range report.Keywords {
+		if keyword.Hits == 0 {
+			continue
+		}
+
+		var winning []string
+		for documentType, count := range keyword.WinningTypes {
+			winning = append(winning, fmt.Sprintf("%s %d", documentType, count))
+		}
+		sort.Strings(winning)
+
+		fmt.Printf("%-25s %4d hits  won: %s\n", keyword.Keyword, keyword.Hits, strings.Join(winning, ", "))
+	}
+
+	fmt.Printf("\n--- Keywords that never matched (%d) ---\n", len(report.DeadKeywords))
+	for _, keyword := range report.DeadKeywords {
+		fmt.Printf("%-25s (%s)\n", keyword.Keyword, strings.Join(keyword.Types, ", "))
+	}
+
+	fmt.Printf("\n--- Keywords shared across rules (%d) ---\n", len(report.SharedKeywords))
+	for _, keyword := range report.SharedKeywords {
+		fmt.Printf("%-25s %4d hits %4d ties  (%s)\n",
+			keyword.Keyword,
+			keyword.Hits,
+			keyword.Ties,
+			strings.Join(keyword.Types, ", "))
+	}
+
+	fmt.Print("\nSave this report as JSON? (y/N): ")
+	answer, _ := ci.ReadLine()
+	if strings.EqualFold(strings.TrimSpace(answer), "y") {
+		reportPath := filepath.Join(ci.processingService.GetOutputDirectory(), "rule_coverage.json")
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(reportPath), 0755)
+		}
+		if err == nil {
+			err = os.WriteFile(reportPath, data, 0644)
+		}
+
+		if err != nil {
+			fmt.Printf("Could not save report: %v\n", err)
+		} else {
+			fmt.Printf("Report saved at: %s\n", reportPath)
+		}
+	}
+
+	fmt.Print("\nPress Enter to return to main menu...")
+	ci.ReadLine()
+	ci.showMainMenu()
+}
@@ -18,7 +18,7 @@ type InputReader interface {
 }
 
 type FormatChecker interface {
-	IsFormatSupported(filename string) bool
+	IsFormatSupported(filePath string) bool
 }
 
 func NewFileBrowser(reader InputReader, formatChecker FormatChecker) *FileBrowser {
@@ -76,7 +76,7 @@ func (fb *FileBrowser) BrowseFilesWithFilter(currentDir string, selectFile bool,
 							break
 						}
 					}
-				} else if fb.formatChecker.IsFormatSupported(entry.Name()) {
+				} else if fb.formatChecker.IsFormatSupported(filepath.Join(currentDir, entry.Name())) {
 					files = append(files, entry)
 				}
 			}